@@ -47,6 +47,12 @@ type ElasticsearchConfig struct {
 
 	// MaxRetries specifies the maximum number of retry attempts for failed requests
 	MaxRetries int `mapstructure:"max_retries"`
+
+	// DocumentRefresh is the default refresh policy for single-document writes (true, false or wait_for)
+	DocumentRefresh string `mapstructure:"document_refresh"`
+
+	// BulkRefresh is the default refresh policy for bulk requests (true, false or wait_for)
+	BulkRefresh string `mapstructure:"bulk_refresh"`
 }
 
 // ServerConfig contains MCP server settings
@@ -66,6 +72,9 @@ type ServerConfig struct {
 
 	// Port for HTTP server (only used when protocol is http)
 	Port int `mapstructure:"port"`
+
+	// IngestDir is the directory bulk ingestion may read files from (empty disables file ingestion)
+	IngestDir string `mapstructure:"ingest_dir"`
 }
 
 // LoadConfig loads configuration from environment variables with default values
@@ -81,13 +90,16 @@ func LoadConfig() (*Config, error) {
 			InsecureSkipVerify: getEnvBool("ES_INSECURE_SKIP_VERIFY", false),
 			Timeout:            getEnvDuration("ES_TIMEOUT", 30*time.Second),
 			MaxRetries:         getEnvInt("ES_MAX_RETRIES", 3),
+			DocumentRefresh:    getEnvString("ES_DOCUMENT_REFRESH", "wait_for"),
+			BulkRefresh:        getEnvString("ES_BULK_REFRESH", "false"),
 		},
 		Server: ServerConfig{
-			Name:     getEnvString("MCP_SERVER_NAME", "Elasticsearch MCP Server"),
-			Version:  getEnvString("MCP_SERVER_VERSION", "1.0.0"),
-			Protocol: getEnvString("MCP_PROTOCOL", "stdio"),
-			Address:  getEnvString("MCP_ADDRESS", "localhost"),
-			Port:     getEnvInt("MCP_PORT", 8080),
+			Name:      getEnvString("MCP_SERVER_NAME", "Elasticsearch MCP Server"),
+			Version:   getEnvString("MCP_SERVER_VERSION", "1.0.0"),
+			Protocol:  getEnvString("MCP_PROTOCOL", "stdio"),
+			Address:   getEnvString("MCP_ADDRESS", "localhost"),
+			Port:      getEnvInt("MCP_PORT", 8080),
+			IngestDir: getEnvString("MCP_INGEST_DIR", ""),
 		},
 	}
 
@@ -105,6 +117,14 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("at least one Elasticsearch address must be specified")
 	}
 
+	if !IsValidRefresh(c.Elasticsearch.DocumentRefresh) {
+		return fmt.Errorf("unsupported document refresh policy: %s, supported values: true, false, wait_for", c.Elasticsearch.DocumentRefresh)
+	}
+
+	if !IsValidRefresh(c.Elasticsearch.BulkRefresh) {
+		return fmt.Errorf("unsupported bulk refresh policy: %s, supported values: true, false, wait_for", c.Elasticsearch.BulkRefresh)
+	}
+
 	if c.Server.Protocol != "stdio" && c.Server.Protocol != "http" && c.Server.Protocol != "sse" {
 		return fmt.Errorf("unsupported protocol: %s, supported protocols: stdio, http, sse (deprecated)", c.Server.Protocol)
 	}
@@ -116,6 +136,11 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// IsValidRefresh reports whether value is a supported refresh policy
+func IsValidRefresh(value string) bool {
+	return value == "true" || value == "false" || value == "wait_for"
+}
+
 // GetElasticsearchVersion returns the Elasticsearch version from environment or default
 func (c *Config) GetElasticsearchVersion() string {
 	// Version can be specified via environment variable, defaults to v8
@@ -4,12 +4,15 @@
 package elasticsearch
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/AeaZer/mcp-elasticsearch/config"
 	elasticsearch8 "github.com/elastic/go-elasticsearch/v8"
@@ -27,15 +30,62 @@ type Client interface {
 	DeleteIndex(ctx context.Context, index string) error
 	IndexExists(ctx context.Context, index string) (bool, error)
 	ListIndices(ctx context.Context) ([]IndexInfo, error)
-
-	Index(ctx context.Context, index, docID string, body map[string]interface{}) (*IndexResponse, error)
-	Get(ctx context.Context, index, docID string) (*GetResponse, error)
-	Delete(ctx context.Context, index, docID string) error
-	Update(ctx context.Context, index, docID string, body map[string]interface{}) error
+	GetMapping(ctx context.Context, index string) (map[string]interface{}, error)
+	PutMapping(ctx context.Context, index string, body map[string]interface{}) error
+	GetSettings(ctx context.Context, index string, includeDefaults bool) (map[string]interface{}, error)
+	PutSettings(ctx context.Context, index string, settings map[string]interface{}) error
+	FieldCaps(ctx context.Context, index string, fields []string) (*FieldCapsResponse, error)
+
+	ListAliases(ctx context.Context) ([]AliasInfo, error)
+	GetAlias(ctx context.Context, index, alias string) (map[string]interface{}, error)
+	UpdateAliases(ctx context.Context, actions []AliasAction) error
+
+	GetIndexTemplates(ctx context.Context, name string) ([]IndexTemplateItem, error)
+	PutIndexTemplate(ctx context.Context, name string, body map[string]interface{}) error
+	DeleteIndexTemplate(ctx context.Context, name string) error
+	SimulateIndexTemplate(ctx context.Context, index string) (map[string]interface{}, error)
+	GetComponentTemplates(ctx context.Context, name string) ([]ComponentTemplateItem, error)
+	PutComponentTemplate(ctx context.Context, name string, body map[string]interface{}) error
+	DeleteComponentTemplate(ctx context.Context, name string) error
+
+	GetDataStreams(ctx context.Context, name string) ([]DataStream, error)
+	CreateDataStream(ctx context.Context, name string) error
+	DeleteDataStream(ctx context.Context, name string) error
+	Rollover(ctx context.Context, target string, conditions map[string]interface{}, dryRun bool) (*RolloverResponse, error)
+
+	GetLifecyclePolicies(ctx context.Context, name string) (map[string]LifecyclePolicy, error)
+	PutLifecyclePolicy(ctx context.Context, name string, policy map[string]interface{}) error
+	DeleteLifecyclePolicy(ctx context.Context, name string) error
+	ExplainLifecycle(ctx context.Context, index string, onlyErrors bool) (map[string]IndexLifecycle, error)
+
+	Index(ctx context.Context, index, docID string, body map[string]interface{}, opts *DocumentOptions) (*IndexResponse, error)
+	Get(ctx context.Context, index, docID string, opts *DocumentOptions) (*GetResponse, error)
+	MGet(ctx context.Context, docs []MGetDoc) ([]MGetItem, error)
+	Delete(ctx context.Context, index, docID string, opts *DocumentOptions) error
+	Update(ctx context.Context, index, docID string, req *UpdateRequest, opts *DocumentOptions) (*IndexResponse, error)
 
 	Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error)
-
-	Bulk(ctx context.Context, operations []BulkOperation) (*BulkResponse, error)
+	OpenPointInTime(ctx context.Context, index, keepAlive string) (string, error)
+	ClosePointInTime(ctx context.Context, pitID string) error
+	AsyncSearchSubmit(ctx context.Context, req *SearchRequest, opts *AsyncSearchOptions) (*AsyncSearchResponse, error)
+	AsyncSearchGet(ctx context.Context, id string, opts *AsyncSearchOptions) (*AsyncSearchResponse, error)
+	AsyncSearchStatus(ctx context.Context, id string) (*AsyncSearchStatus, error)
+	AsyncSearchDelete(ctx context.Context, id string) error
+	Count(ctx context.Context, index string, query map[string]interface{}) (*CountResponse, error)
+	EQLSearch(ctx context.Context, req *EQLSearchRequest) (*EQLResponse, error)
+	EQLGet(ctx context.Context, id string, waitForCompletionTimeout time.Duration) (*EQLResponse, error)
+
+	SQL(ctx context.Context, req *SQLRequest) (*SQLResponse, error)
+	SQLTranslate(ctx context.Context, req *SQLRequest) (map[string]interface{}, error)
+	ESQL(ctx context.Context, req *ESQLRequest) (*ESQLResponse, error)
+
+	Bulk(ctx context.Context, operations []BulkOperation, opts *BulkOptions) (*BulkResponse, error)
+	BulkIngest(ctx context.Context, req *BulkIngestRequest) (*BulkIngestResponse, error)
+
+	Reindex(ctx context.Context, req *ReindexRequest) (string, error)
+	UpdateByQuery(ctx context.Context, req *ByQueryRequest) (*ByQueryResponse, error)
+	DeleteByQuery(ctx context.Context, req *ByQueryRequest) (*ByQueryResponse, error)
+	GetTask(ctx context.Context, taskID string) (*TaskResponse, error)
 
 	Close() error
 }
@@ -246,33 +296,24 @@ func (c *ESClient) ListIndices(ctx context.Context) ([]IndexInfo, error) {
 	return indices, nil
 }
 
-// Index adds or updates a document in Elasticsearch.
+// GetMapping retrieves the mappings of one or more indices.
 //
 // Parameters:
 //   - ctx: Context for request cancellation
-//   - index: Name of the target index
-//   - docID: Document ID (empty string for auto-generation)
-//   - body: Document content as a map
+//   - index: Name of the index (or pattern); empty for all indices
 //
 // Returns:
-//   - *IndexResponse: Response containing operation details
-//   - error: Any error that occurred during indexing
-func (c *ESClient) Index(ctx context.Context, index, docID string, body map[string]interface{}) (*IndexResponse, error) {
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to serialize document: %w", err)
-	}
-
-	req := esapi.IndexRequest{
-		Index:      index,
-		DocumentID: docID,
-		Body:       &bodyReader{data: bodyBytes},
-		Refresh:    "true",
+//   - map[string]interface{}: Mappings keyed by index name
+//   - error: Any error that occurred during the operation
+func (c *ESClient) GetMapping(ctx context.Context, index string) (map[string]interface{}, error) {
+	var req esapi.IndicesGetMappingRequest
+	if index != "" {
+		req.Index = []string{index}
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to index document: %w", err)
+		return nil, fmt.Errorf("failed to get mapping: %w", err)
 	}
 	defer res.Body.Close()
 
@@ -280,245 +321,1900 @@ func (c *ESClient) Index(ctx context.Context, index, docID string, body map[stri
 		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
 	}
 
-	var indexResp IndexResponse
-	if err := json.NewDecoder(res.Body).Decode(&indexResp); err != nil {
+	var mappings map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&mappings); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return &indexResp, nil
+	return mappings, nil
 }
 
-// Get retrieves a document from Elasticsearch by its ID.
+// PutMapping adds new fields or updates mapping parameters of an existing index.
+// Illegal changes such as changing a field type are returned as *ResponseError.
 //
 // Parameters:
 //   - ctx: Context for request cancellation
-//   - index: Name of the source index
-//   - docID: Document ID to retrieve
+//   - index: Name of the index (or pattern) to update
+//   - body: Mapping definition to merge (e.g. properties, dynamic)
+func (c *ESClient) PutMapping(ctx context.Context, index string, body map[string]interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	req := esapi.IndicesPutMappingRequest{
+		Index: []string{index},
+		Body:  &bodyReader{data: bodyBytes},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to put mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// GetSettings retrieves the settings of one or more indices.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - index: Name of the index (or pattern); empty for all indices
+//   - includeDefaults: Whether to also return default values for unset settings
 //
 // Returns:
-//   - *GetResponse: Response containing the document
-//   - error: Any error that occurred during retrieval
-func (c *ESClient) Get(ctx context.Context, index, docID string) (*GetResponse, error) {
-	req := esapi.GetRequest{
-		Index:      index,
-		DocumentID: docID,
+//   - map[string]interface{}: Settings keyed by index name
+//   - error: Any error that occurred during the operation
+func (c *ESClient) GetSettings(ctx context.Context, index string, includeDefaults bool) (map[string]interface{}, error) {
+	var req esapi.IndicesGetSettingsRequest
+	if index != "" {
+		req.Index = []string{index}
+	}
+	if includeDefaults {
+		req.IncludeDefaults = &includeDefaults
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get document: %w", err)
+		return nil, fmt.Errorf("failed to get settings: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		if res.StatusCode == 404 {
-			return nil, fmt.Errorf("document not found")
-		}
 		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
 	}
 
-	var getResp GetResponse
-	if err := json.NewDecoder(res.Body).Decode(&getResp); err != nil {
+	var settings map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&settings); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return &getResp, nil
+	return settings, nil
 }
 
-// Delete removes a document from Elasticsearch.
+// PutSettings updates dynamic settings (e.g. number_of_replicas,
+// refresh_interval) of an existing index.
+// Rejected changes are returned as *ResponseError.
 //
 // Parameters:
 //   - ctx: Context for request cancellation
-//   - index: Name of the source index
-//   - docID: Document ID to delete
-func (c *ESClient) Delete(ctx context.Context, index, docID string) error {
-	req := esapi.DeleteRequest{
-		Index:      index,
-		DocumentID: docID,
-		Refresh:    "true",
+//   - index: Name of the index (or pattern) to update
+//   - settings: Settings to apply
+func (c *ESClient) PutSettings(ctx context.Context, index string, settings map[string]interface{}) error {
+	bodyBytes, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	req := esapi.IndicesPutSettingsRequest{
+		Index: []string{index},
+		Body:  &bodyReader{data: bodyBytes},
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("failed to delete document: %w", err)
+		return fmt.Errorf("failed to put settings: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.IsError() && res.StatusCode != 404 {
-		return fmt.Errorf("elasticsearch error: %s", res.String())
+	if res.IsError() {
+		return newResponseError(res)
 	}
 
 	return nil
 }
 
-// Update partially updates a document in Elasticsearch.
+// FieldCaps retrieves the capabilities of fields across one or more indices,
+// such as their type and whether they are searchable or aggregatable.
 //
 // Parameters:
 //   - ctx: Context for request cancellation
-//   - index: Name of the target index
-//   - docID: Document ID to update
-//   - body: Partial document content for update
-func (c *ESClient) Update(ctx context.Context, index, docID string, body map[string]interface{}) error {
-	updateBody := map[string]interface{}{
-		"doc": body,
+//   - index: Name of the index (or pattern); empty for all indices
+//   - fields: Field names or wildcard patterns to inspect
+//
+// Returns:
+//   - *FieldCapsResponse: Capabilities keyed by field name and type
+//   - error: Any error that occurred during the operation
+func (c *ESClient) FieldCaps(ctx context.Context, index string, fields []string) (*FieldCapsResponse, error) {
+	req := esapi.FieldCapsRequest{
+		Fields: fields,
+	}
+	if index != "" {
+		req.Index = []string{index}
 	}
 
-	bodyBytes, err := json.Marshal(updateBody)
+	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("failed to serialize update body: %w", err)
+		return nil, fmt.Errorf("failed to get field capabilities: %w", err)
 	}
+	defer res.Body.Close()
 
-	req := esapi.UpdateRequest{
-		Index:      index,
-		DocumentID: docID,
-		Body:       &bodyReader{data: bodyBytes},
-		Refresh:    "true",
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	var fieldCaps FieldCapsResponse
+	if err := json.NewDecoder(res.Body).Decode(&fieldCaps); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &fieldCaps, nil
+}
+
+// ListAliases retrieves all aliases in the cluster with their target indices.
+//
+// Returns:
+//   - []AliasInfo: List of alias information, one entry per alias and index
+//   - error: Any error that occurred during the operation
+func (c *ESClient) ListAliases(ctx context.Context) ([]AliasInfo, error) {
+	req := esapi.CatAliasesRequest{
+		Format: "json",
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("failed to update document: %w", err)
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return fmt.Errorf("elasticsearch error: %s", res.String())
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
 	}
 
-	return nil
+	var aliases []AliasInfo
+	if err := json.NewDecoder(res.Body).Decode(&aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return aliases, nil
 }
 
-// Search executes a search query against Elasticsearch.
+// GetAlias retrieves alias definitions, including filters and routing.
 //
 // Parameters:
 //   - ctx: Context for request cancellation
-//   - req: Search request containing query, index, pagination, etc.
+//   - index: Index name or pattern to restrict the lookup (empty for all indices)
+//   - alias: Alias name or pattern (empty for all aliases)
 //
 // Returns:
-//   - *SearchResponse: Search results with hits and metadata
-//   - error: Any error that occurred during search
-func (c *ESClient) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
-	// Build complete search request body
-	searchBody := make(map[string]interface{})
+//   - map[string]interface{}: Alias definitions keyed by index name
+//   - error: Any error that occurred during the operation
+func (c *ESClient) GetAlias(ctx context.Context, index, alias string) (map[string]interface{}, error) {
+	var req esapi.IndicesGetAliasRequest
+	if index != "" {
+		req.Index = []string{index}
+	}
+	if alias != "" {
+		req.Name = []string{alias}
+	}
 
-	// Add query
-	if req.Query != nil {
-		searchBody["query"] = req.Query
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alias: %w", err)
 	}
+	defer res.Body.Close()
 
-	// Add sort if provided
-	if req.Sort != nil && len(req.Sort) > 0 {
-		searchBody["sort"] = req.Sort
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, fmt.Errorf("alias not found")
+		}
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
 	}
 
-	// Add _source if provided
-	if req.Source != nil {
-		searchBody["_source"] = req.Source
+	var aliases map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// UpdateAliases applies a list of alias actions atomically in a single request.
+// Either all actions succeed or none are applied.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - actions: Alias actions to apply, in order
+func (c *ESClient) UpdateAliases(ctx context.Context, actions []AliasAction) error {
+	// Convert each action to the {"<action>": {...}} form expected by the API
+	bodyActions := make([]map[string]interface{}, 0, len(actions))
+	for _, action := range actions {
+		params := map[string]interface{}{
+			"index": action.Index,
+		}
+		if action.Alias != "" {
+			params["alias"] = action.Alias
+		}
+		if action.Filter != nil {
+			params["filter"] = action.Filter
+		}
+		if action.Routing != "" {
+			params["routing"] = action.Routing
+		}
+		if action.IndexRouting != "" {
+			params["index_routing"] = action.IndexRouting
+		}
+		if action.SearchRouting != "" {
+			params["search_routing"] = action.SearchRouting
+		}
+		if action.IsWriteIndex != nil {
+			params["is_write_index"] = *action.IsWriteIndex
+		}
+		bodyActions = append(bodyActions, map[string]interface{}{
+			action.Action: params,
+		})
 	}
 
-	bodyBytes, err := json.Marshal(searchBody)
+	bodyBytes, err := json.Marshal(map[string]interface{}{"actions": bodyActions})
 	if err != nil {
-		return nil, fmt.Errorf("failed to serialize search request: %w", err)
+		return fmt.Errorf("failed to serialize alias actions: %w", err)
 	}
 
-	// Debug: log the actual request body being sent to Elasticsearch
-	log.Printf("Elasticsearch search request body: %s", string(bodyBytes))
+	req := esapi.IndicesUpdateAliasesRequest{
+		Body: &bodyReader{data: bodyBytes},
+	}
 
-	// Handle index parameter (can be empty for searching all indices)
-	var indices []string
-	if req.Index != "" {
-		indices = []string{req.Index}
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to update aliases: %w", err)
 	}
+	defer res.Body.Close()
 
-	esReq := esapi.SearchRequest{
-		Index: indices,
-		Body:  &bodyReader{data: bodyBytes},
-		Size:  &req.Size,
-		From:  &req.From,
+	if res.IsError() {
+		return newResponseError(res)
 	}
 
-	res, err := esReq.Do(ctx, c.client)
+	return nil
+}
+
+// GetIndexTemplates retrieves composable index templates.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Template name or wildcard pattern (empty for all templates)
+//
+// Returns:
+//   - []IndexTemplateItem: Matching index templates
+//   - error: Any error that occurred during the operation
+func (c *ESClient) GetIndexTemplates(ctx context.Context, name string) ([]IndexTemplateItem, error) {
+	req := esapi.IndicesGetIndexTemplateRequest{
+		Name: name,
+	}
+
+	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return nil, fmt.Errorf("search failed: %w", err)
+		return nil, fmt.Errorf("failed to get index templates: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, fmt.Errorf("index template not found")
+		}
 		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
 	}
 
-	var searchResp SearchResponse
-	if err := json.NewDecoder(res.Body).Decode(&searchResp); err != nil {
-		return nil, fmt.Errorf("failed to parse search response: %w", err)
+	var templatesResp struct {
+		IndexTemplates []IndexTemplateItem `json:"index_templates"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&templatesResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return &searchResp, nil
+	return templatesResp.IndexTemplates, nil
 }
 
-// Bulk performs multiple operations in a single request.
-// This is more efficient than individual operations for large datasets.
+// PutIndexTemplate creates or replaces a composable index template.
 //
 // Parameters:
 //   - ctx: Context for request cancellation
-//   - operations: List of bulk operations to perform
+//   - name: Name of the index template
+//   - body: Template definition (index_patterns, template, composed_of, priority, etc.)
+func (c *ESClient) PutIndexTemplate(ctx context.Context, name string, body map[string]interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	req := esapi.IndicesPutIndexTemplateRequest{
+		Name: name,
+		Body: &bodyReader{data: bodyBytes},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to put index template: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// DeleteIndexTemplate removes a composable index template.
 //
-// Returns:
-//   - *BulkResponse: Results of all bulk operations
-//   - error: Any error that occurred during bulk operation
-func (c *ESClient) Bulk(ctx context.Context, operations []BulkOperation) (*BulkResponse, error) {
-	// Build the bulk request body in NDJSON format
-	body := ""
-	for _, op := range operations {
-		// Create the action line based on operation type
-		action := map[string]interface{}{
-			op.Operation: map[string]interface{}{
-				"_index": op.Index,
-			},
-		}
-		if op.Type != "" {
-			action[op.Operation].(map[string]interface{})["_type"] = op.Type
-		}
-		if op.ID != "" {
-			action[op.Operation].(map[string]interface{})["_id"] = op.ID
-		}
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Name of the index template to delete
+func (c *ESClient) DeleteIndexTemplate(ctx context.Context, name string) error {
+	req := esapi.IndicesDeleteIndexTemplateRequest{
+		Name: name,
+	}
 
-		actionBytes, err := json.Marshal(action)
-		if err != nil {
-			return nil, fmt.Errorf("failed to serialize bulk operation: %w", err)
-		}
-		body += string(actionBytes) + "\n"
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete index template: %w", err)
+	}
+	defer res.Body.Close()
 
-		// Add the source document if it exists (not needed for delete operations)
-		if op.Body != nil && op.Operation != "delete" {
-			sourceBytes, err := json.Marshal(op.Body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to serialize bulk operation source: %w", err)
-			}
-			body += string(sourceBytes) + "\n"
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return fmt.Errorf("index template not found")
 		}
+		return newResponseError(res)
 	}
 
-	req := esapi.BulkRequest{
-		Body:    &bodyReader{data: []byte(body)},
-		Refresh: "true",
+	return nil
+}
+
+// SimulateIndexTemplate previews the settings, mappings and aliases that an
+// index with the given name would receive from the matching index templates.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - index: Name of the (not necessarily existing) index to simulate
+//
+// Returns:
+//   - map[string]interface{}: Resolved template and overlapping templates
+//   - error: Any error that occurred during the operation
+func (c *ESClient) SimulateIndexTemplate(ctx context.Context, index string) (map[string]interface{}, error) {
+	req := esapi.IndicesSimulateIndexTemplateRequest{
+		Name: index,
 	}
 
 	res, err := req.Do(ctx, c.client)
 	if err != nil {
-		return nil, fmt.Errorf("bulk operation failed: %w", err)
+		return nil, fmt.Errorf("failed to simulate index template: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+		return nil, newResponseError(res)
 	}
 
-	var bulkResp BulkResponse
-	if err := json.NewDecoder(res.Body).Decode(&bulkResp); err != nil {
-		return nil, fmt.Errorf("failed to parse bulk response: %w", err)
+	var simulation map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&simulation); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return &bulkResp, nil
+	return simulation, nil
+}
+
+// GetComponentTemplates retrieves component templates.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Template name or wildcard pattern (empty for all templates)
+//
+// Returns:
+//   - []ComponentTemplateItem: Matching component templates
+//   - error: Any error that occurred during the operation
+func (c *ESClient) GetComponentTemplates(ctx context.Context, name string) ([]ComponentTemplateItem, error) {
+	var req esapi.ClusterGetComponentTemplateRequest
+	if name != "" {
+		req.Name = []string{name}
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get component templates: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, fmt.Errorf("component template not found")
+		}
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	var templatesResp struct {
+		ComponentTemplates []ComponentTemplateItem `json:"component_templates"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&templatesResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return templatesResp.ComponentTemplates, nil
+}
+
+// PutComponentTemplate creates or replaces a component template.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Name of the component template
+//   - body: Template definition (template with settings/mappings/aliases, version, _meta)
+func (c *ESClient) PutComponentTemplate(ctx context.Context, name string, body map[string]interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	req := esapi.ClusterPutComponentTemplateRequest{
+		Name: name,
+		Body: &bodyReader{data: bodyBytes},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to put component template: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// DeleteComponentTemplate removes a component template.
+// Templates still referenced by an index template cannot be deleted.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Name of the component template to delete
+func (c *ESClient) DeleteComponentTemplate(ctx context.Context, name string) error {
+	req := esapi.ClusterDeleteComponentTemplateRequest{
+		Name: name,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete component template: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return fmt.Errorf("component template not found")
+		}
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// GetDataStreams retrieves data streams with their backing indices and generation.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Data stream name or wildcard pattern (empty for all data streams)
+//
+// Returns:
+//   - []DataStream: Matching data streams
+//   - error: Any error that occurred during the operation
+func (c *ESClient) GetDataStreams(ctx context.Context, name string) ([]DataStream, error) {
+	var req esapi.IndicesGetDataStreamRequest
+	if name != "" {
+		req.Name = []string{name}
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data streams: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, fmt.Errorf("data stream not found")
+		}
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	var dataStreamsResp struct {
+		DataStreams []DataStream `json:"data_streams"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&dataStreamsResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return dataStreamsResp.DataStreams, nil
+}
+
+// CreateDataStream creates a data stream. A matching index template with
+// data_stream enabled must already exist.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Name of the data stream to create
+func (c *ESClient) CreateDataStream(ctx context.Context, name string) error {
+	req := esapi.IndicesCreateDataStreamRequest{
+		Name: name,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to create data stream: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// DeleteDataStream deletes a data stream together with all of its backing indices.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Name of the data stream to delete
+func (c *ESClient) DeleteDataStream(ctx context.Context, name string) error {
+	req := esapi.IndicesDeleteDataStreamRequest{
+		Name: []string{name},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete data stream: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return fmt.Errorf("data stream not found")
+		}
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// Rollover creates a new write index for a data stream or index alias.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - target: Name of the data stream or alias to roll over
+//   - conditions: Optional conditions (e.g. max_age, max_docs); rolls over unconditionally if empty
+//   - dryRun: Only evaluate the conditions without rolling over
+//
+// Returns:
+//   - *RolloverResponse: Old and new index names and evaluated conditions
+//   - error: Any error that occurred during the rollover
+func (c *ESClient) Rollover(ctx context.Context, target string, conditions map[string]interface{}, dryRun bool) (*RolloverResponse, error) {
+	req := esapi.IndicesRolloverRequest{
+		Alias: target,
+	}
+
+	if len(conditions) > 0 {
+		bodyBytes, err := json.Marshal(map[string]interface{}{"conditions": conditions})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize request body: %w", err)
+		}
+		req.Body = &bodyReader{data: bodyBytes}
+	}
+	if dryRun {
+		req.DryRun = &dryRun
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll over: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var rolloverResp RolloverResponse
+	if err := json.NewDecoder(res.Body).Decode(&rolloverResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &rolloverResp, nil
+}
+
+// GetLifecyclePolicies retrieves ILM policies.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Policy name (empty for all policies)
+//
+// Returns:
+//   - map[string]LifecyclePolicy: Policies keyed by policy name
+//   - error: Any error that occurred during the operation
+func (c *ESClient) GetLifecyclePolicies(ctx context.Context, name string) (map[string]LifecyclePolicy, error) {
+	req := esapi.ILMGetLifecycleRequest{
+		Policy: name,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lifecycle policies: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, fmt.Errorf("lifecycle policy not found")
+		}
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	var policies map[string]LifecyclePolicy
+	if err := json.NewDecoder(res.Body).Decode(&policies); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return policies, nil
+}
+
+// PutLifecyclePolicy creates or replaces an ILM policy.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Policy name
+//   - policy: Policy definition (phases and optional _meta)
+func (c *ESClient) PutLifecyclePolicy(ctx context.Context, name string, policy map[string]interface{}) error {
+	bodyBytes, err := json.Marshal(map[string]interface{}{"policy": policy})
+	if err != nil {
+		return fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	req := esapi.ILMPutLifecycleRequest{
+		Policy: name,
+		Body:   &bodyReader{data: bodyBytes},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to put lifecycle policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// DeleteLifecyclePolicy removes an ILM policy that is not in use by any index.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - name: Policy name
+func (c *ESClient) DeleteLifecyclePolicy(ctx context.Context, name string) error {
+	req := esapi.ILMDeleteLifecycleRequest{
+		Policy: name,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete lifecycle policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return fmt.Errorf("lifecycle policy not found")
+		}
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// ExplainLifecycle reports the current ILM phase, action and step of indices,
+// including step errors for indices that are stuck.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - index: Index name, data stream or pattern
+//   - onlyErrors: Only report indices whose lifecycle step failed
+//
+// Returns:
+//   - map[string]IndexLifecycle: Lifecycle state keyed by index name
+//   - error: Any error that occurred during the operation
+func (c *ESClient) ExplainLifecycle(ctx context.Context, index string, onlyErrors bool) (map[string]IndexLifecycle, error) {
+	req := esapi.ILMExplainLifecycleRequest{
+		Index: index,
+	}
+	if onlyErrors {
+		req.OnlyErrors = &onlyErrors
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain lifecycle: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var explainResp struct {
+		Indices map[string]IndexLifecycle `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&explainResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return explainResp.Indices, nil
+}
+
+// Index adds or updates a document in Elasticsearch.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - index: Name of the target index
+//   - docID: Document ID (empty string for auto-generation)
+//   - body: Document content as a map
+//   - opts: Optional routing, op_type, pipeline, refresh and concurrency control parameters (may be nil)
+//
+// Returns:
+//   - *IndexResponse: Response containing operation details
+//   - error: Any error that occurred during indexing; version conflicts match ErrVersionConflict
+func (c *ESClient) Index(ctx context.Context, index, docID string, body map[string]interface{}, opts *DocumentOptions) (*IndexResponse, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize document: %w", err)
+	}
+
+	req := esapi.IndexRequest{
+		Index:      index,
+		DocumentID: docID,
+		Body:       &bodyReader{data: bodyBytes},
+		Refresh:    c.documentRefresh(opts),
+	}
+	if opts != nil {
+		req.Routing = opts.Routing
+		req.OpType = opts.OpType
+		req.Pipeline = opts.Pipeline
+		req.IfSeqNo = opts.IfSeqNo
+		req.IfPrimaryTerm = opts.IfPrimaryTerm
+		req.Version = opts.Version
+		req.VersionType = opts.VersionType
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to index document: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var indexResp IndexResponse
+	if err := json.NewDecoder(res.Body).Decode(&indexResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &indexResp, nil
+}
+
+// Get retrieves a document from Elasticsearch by its ID.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - index: Name of the source index
+//   - docID: Document ID to retrieve
+//   - opts: Optional routing parameter (may be nil)
+//
+// Returns:
+//   - *GetResponse: Response containing the document
+//   - error: Any error that occurred during retrieval
+func (c *ESClient) Get(ctx context.Context, index, docID string, opts *DocumentOptions) (*GetResponse, error) {
+	req := esapi.GetRequest{
+		Index:      index,
+		DocumentID: docID,
+	}
+	if opts != nil {
+		req.Routing = opts.Routing
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, fmt.Errorf("document not found")
+		}
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	var getResp GetResponse
+	if err := json.NewDecoder(res.Body).Decode(&getResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &getResp, nil
+}
+
+// MGet retrieves multiple documents, possibly from different indices, in a single request.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - docs: Documents to retrieve with optional routing and source filtering
+//
+// Returns:
+//   - []MGetItem: One result per requested document, in request order
+//   - error: Any error that occurred during retrieval
+func (c *ESClient) MGet(ctx context.Context, docs []MGetDoc) ([]MGetItem, error) {
+	bodyBytes, err := json.Marshal(map[string]interface{}{"docs": docs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize multi-get request: %w", err)
+	}
+
+	req := esapi.MgetRequest{
+		Body: &bodyReader{data: bodyBytes},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var mgetResp struct {
+		Docs []MGetItem `json:"docs"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&mgetResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return mgetResp.Docs, nil
+}
+
+// Delete removes a document from Elasticsearch.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - index: Name of the source index
+//   - docID: Document ID to delete
+//   - opts: Optional routing, refresh and concurrency control parameters (may be nil)
+//
+// Version conflicts are returned as errors matching ErrVersionConflict.
+func (c *ESClient) Delete(ctx context.Context, index, docID string, opts *DocumentOptions) error {
+	req := esapi.DeleteRequest{
+		Index:      index,
+		DocumentID: docID,
+		Refresh:    c.documentRefresh(opts),
+	}
+	if opts != nil {
+		req.Routing = opts.Routing
+		req.IfSeqNo = opts.IfSeqNo
+		req.IfPrimaryTerm = opts.IfPrimaryTerm
+		req.Version = opts.Version
+		req.VersionType = opts.VersionType
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != 404 {
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// Update updates a document in Elasticsearch with a partial document or a script.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - index: Name of the target index
+//   - docID: Document ID to update
+//   - req: Update request with doc or script and upsert options
+//   - opts: Optional routing, refresh and concurrency control parameters (may be nil)
+//
+// Returns:
+//   - *IndexResponse: Response containing the resulting version and result
+//   - error: Any error that occurred during the update; version conflicts match ErrVersionConflict
+func (c *ESClient) Update(ctx context.Context, index, docID string, req *UpdateRequest, opts *DocumentOptions) (*IndexResponse, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize update body: %w", err)
+	}
+
+	esReq := esapi.UpdateRequest{
+		Index:      index,
+		DocumentID: docID,
+		Body:       &bodyReader{data: bodyBytes},
+		Refresh:    c.documentRefresh(opts),
+	}
+	if req.RetryOnConflict > 0 {
+		esReq.RetryOnConflict = &req.RetryOnConflict
+	}
+	if opts != nil {
+		esReq.Routing = opts.Routing
+		esReq.IfSeqNo = opts.IfSeqNo
+		esReq.IfPrimaryTerm = opts.IfPrimaryTerm
+	}
+
+	res, err := esReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update document: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var updateResp IndexResponse
+	if err := json.NewDecoder(res.Body).Decode(&updateResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &updateResp, nil
+}
+
+// Search executes a search query against Elasticsearch.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: Search request containing query, index, pagination, etc.
+//
+// Returns:
+//   - *SearchResponse: Search results with hits and metadata
+//   - error: Any error that occurred during search
+func (c *ESClient) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	bodyBytes, err := json.Marshal(buildSearchBody(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize search request: %w", err)
+	}
+
+	// Debug: log the actual request body being sent to Elasticsearch
+	log.Printf("Elasticsearch search request body: %s", string(bodyBytes))
+
+	// Handle index parameter (can be empty for searching all indices).
+	// A point in time is already bound to its indices, so none may be given.
+	var indices []string
+	if req.Index != "" && req.PIT == nil {
+		indices = []string{req.Index}
+	}
+
+	esReq := esapi.SearchRequest{
+		Index: indices,
+		Body:  &bodyReader{data: bodyBytes},
+		Size:  &req.Size,
+	}
+
+	if req.IncludeNamedQueriesScore {
+		esReq.IncludeNamedQueriesScore = &req.IncludeNamedQueriesScore
+	}
+
+	// search_after pages are positioned by sort values, not by offset
+	if len(req.SearchAfter) == 0 {
+		esReq.From = &req.From
+	}
+
+	res, err := esReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	// Decode numbers losslessly so that sort values (e.g. the _shard_doc
+	// tiebreaker) can be passed back verbatim as search_after
+	decoder := json.NewDecoder(res.Body)
+	decoder.UseNumber()
+
+	var searchResp SearchResponse
+	if err := decoder.Decode(&searchResp); err != nil {
+		return nil, fmt.Errorf("failed to parse search response: %w", err)
+	}
+
+	return &searchResp, nil
+}
+
+// buildSearchBody builds the search request body shared by Search and AsyncSearchSubmit.
+// Index, size and from are sent as URL parameters instead.
+func buildSearchBody(req *SearchRequest) map[string]interface{} {
+	searchBody := make(map[string]interface{})
+
+	// Add query
+	if req.Query != nil {
+		searchBody["query"] = req.Query
+	}
+
+	// Add sort if provided
+	if req.Sort != nil && len(req.Sort) > 0 {
+		searchBody["sort"] = req.Sort
+	}
+
+	// Add _source if provided
+	if req.Source != nil {
+		searchBody["_source"] = req.Source
+	}
+
+	// Add aggregations if provided
+	if len(req.Aggs) > 0 {
+		searchBody["aggs"] = req.Aggs
+	}
+
+	// Add highlighting, retrieved fields and scoring explanation if requested
+	if len(req.Highlight) > 0 {
+		searchBody["highlight"] = req.Highlight
+	}
+	if len(req.Fields) > 0 {
+		searchBody["fields"] = req.Fields
+	}
+	if req.Explain {
+		searchBody["explain"] = true
+	}
+
+	// Add kNN vector search and result fusion if provided
+	if req.Knn != nil {
+		searchBody["knn"] = req.Knn
+	}
+	if len(req.Rank) > 0 {
+		searchBody["rank"] = req.Rank
+	}
+
+	// Add search_after and point in time for deep pagination
+	if len(req.SearchAfter) > 0 {
+		searchBody["search_after"] = req.SearchAfter
+	}
+	if req.PIT != nil {
+		searchBody["pit"] = req.PIT
+	}
+
+	return searchBody
+}
+
+// OpenPointInTime opens a point in time on the given index so that
+// subsequent searches see a consistent view of the data.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - index: Name of the index (or pattern) to open the point in time on
+//   - keepAlive: How long the point in time is kept alive (e.g. "1m")
+//
+// Returns:
+//   - string: The point in time ID to pass to subsequent searches
+//   - error: Any error that occurred while opening the point in time
+func (c *ESClient) OpenPointInTime(ctx context.Context, index, keepAlive string) (string, error) {
+	req := esapi.OpenPointInTimeRequest{
+		Index:     []string{index},
+		KeepAlive: keepAlive,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return "", fmt.Errorf("failed to open point in time: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	var pitResp struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&pitResp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return pitResp.ID, nil
+}
+
+// ClosePointInTime releases a point in time previously opened with OpenPointInTime.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - pitID: The point in time ID to close
+func (c *ESClient) ClosePointInTime(ctx context.Context, pitID string) error {
+	bodyBytes, err := json.Marshal(map[string]interface{}{"id": pitID})
+	if err != nil {
+		return fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	req := esapi.ClosePointInTimeRequest{
+		Body: &bodyReader{data: bodyBytes},
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to close point in time: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	return nil
+}
+
+// AsyncSearchSubmit starts a search that keeps running in the background if it
+// does not complete within the wait timeout.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: Search request (point in time and search_after are not supported)
+//   - opts: Optional wait timeout and keep alive (may be nil)
+//
+// Returns:
+//   - *AsyncSearchResponse: Search ID, state and current results
+//   - error: Any error that occurred while submitting the search
+func (c *ESClient) AsyncSearchSubmit(ctx context.Context, req *SearchRequest, opts *AsyncSearchOptions) (*AsyncSearchResponse, error) {
+	if req.PIT != nil || len(req.SearchAfter) > 0 {
+		return nil, fmt.Errorf("async search does not support point in time or search_after")
+	}
+
+	bodyBytes, err := json.Marshal(buildSearchBody(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize search request: %w", err)
+	}
+
+	var indices []string
+	if req.Index != "" {
+		indices = []string{req.Index}
+	}
+
+	// Size is always sent like in Search, since 0 is meaningful for aggregation-only requests
+	esReq := esapi.AsyncSearchSubmitRequest{
+		Index: indices,
+		Body:  &bodyReader{data: bodyBytes},
+		Size:  &req.Size,
+	}
+	if req.From > 0 {
+		esReq.From = &req.From
+	}
+	if opts != nil {
+		esReq.WaitForCompletionTimeout = opts.WaitForCompletionTimeout
+		esReq.KeepAlive = opts.KeepAlive
+	}
+
+	res, err := esReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit async search: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	return decodeAsyncSearchResponse(res.Body)
+}
+
+// AsyncSearchGet retrieves the state and current results of an async search.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - id: ID returned by AsyncSearchSubmit
+//   - opts: Optional wait timeout and new keep alive (may be nil)
+//
+// Returns:
+//   - *AsyncSearchResponse: Search state and current, possibly partial, results
+//   - error: Any error that occurred during retrieval
+func (c *ESClient) AsyncSearchGet(ctx context.Context, id string, opts *AsyncSearchOptions) (*AsyncSearchResponse, error) {
+	req := esapi.AsyncSearchGetRequest{
+		DocumentID: id,
+	}
+	if opts != nil {
+		req.WaitForCompletionTimeout = opts.WaitForCompletionTimeout
+		req.KeepAlive = opts.KeepAlive
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get async search: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	return decodeAsyncSearchResponse(res.Body)
+}
+
+// AsyncSearchStatus retrieves the progress of an async search without its results.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - id: ID returned by AsyncSearchSubmit
+//
+// Returns:
+//   - *AsyncSearchStatus: Search state and shard progress
+//   - error: Any error that occurred during retrieval
+func (c *ESClient) AsyncSearchStatus(ctx context.Context, id string) (*AsyncSearchStatus, error) {
+	req := esapi.AsyncSearchStatusRequest{
+		DocumentID: id,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get async search status: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var status AsyncSearchStatus
+	if err := json.NewDecoder(res.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &status, nil
+}
+
+// AsyncSearchDelete cancels an async search if it is still running and deletes its results.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - id: ID returned by AsyncSearchSubmit
+func (c *ESClient) AsyncSearchDelete(ctx context.Context, id string) error {
+	req := esapi.AsyncSearchDeleteRequest{
+		DocumentID: id,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete async search: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return newResponseError(res)
+	}
+
+	return nil
+}
+
+// decodeAsyncSearchResponse parses an async search response, keeping numbers
+// lossless like Search does for sort values
+func decodeAsyncSearchResponse(body io.Reader) (*AsyncSearchResponse, error) {
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+
+	var asyncResp AsyncSearchResponse
+	if err := decoder.Decode(&asyncResp); err != nil {
+		return nil, fmt.Errorf("failed to parse async search response: %w", err)
+	}
+
+	return &asyncResp, nil
+}
+
+// Count returns the exact number of documents matching a query.
+// Unlike Search it does not fetch any hits and is not capped at 10,000.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - index: Name of the index (empty for all indices)
+//   - query: Query DSL to match documents (nil counts all documents)
+//
+// Returns:
+//   - *CountResponse: Response containing the document count
+//   - error: Any error that occurred during counting
+func (c *ESClient) Count(ctx context.Context, index string, query map[string]interface{}) (*CountResponse, error) {
+	var req esapi.CountRequest
+	if index != "" {
+		req.Index = []string{index}
+	}
+
+	if query != nil {
+		bodyBytes, err := json.Marshal(map[string]interface{}{"query": query})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize count request: %w", err)
+		}
+		req.Body = &bodyReader{data: bodyBytes}
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("count failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	var countResp CountResponse
+	if err := json.NewDecoder(res.Body).Decode(&countResp); err != nil {
+		return nil, fmt.Errorf("failed to parse count response: %w", err)
+	}
+
+	return &countResp, nil
+}
+
+// EQLSearch runs an EQL query for events or sequences of events.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: EQL query with event fields, filter, sizes and async options
+//
+// Returns:
+//   - *EQLResponse: Matched events or sequences, or the ID of the still running search
+//   - error: Any error that occurred during the search
+func (c *ESClient) EQLSearch(ctx context.Context, req *EQLSearchRequest) (*EQLResponse, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize EQL request: %w", err)
+	}
+
+	eqlReq := esapi.EqlSearchRequest{
+		Index:                    req.Index,
+		Body:                     &bodyReader{data: bodyBytes},
+		WaitForCompletionTimeout: req.WaitForCompletionTimeout,
+		KeepAlive:                req.KeepAlive,
+	}
+
+	res, err := eqlReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("EQL search failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var eqlResp EQLResponse
+	if err := json.NewDecoder(res.Body).Decode(&eqlResp); err != nil {
+		return nil, fmt.Errorf("failed to parse EQL response: %w", err)
+	}
+
+	return &eqlResp, nil
+}
+
+// EQLGet retrieves the results of an asynchronous EQL search.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - id: ID returned by EQLSearch
+//   - waitForCompletionTimeout: How long to wait for the search to complete (0 waits until complete)
+//
+// Returns:
+//   - *EQLResponse: Current, possibly partial, results of the search
+//   - error: Any error that occurred during retrieval
+func (c *ESClient) EQLGet(ctx context.Context, id string, waitForCompletionTimeout time.Duration) (*EQLResponse, error) {
+	req := esapi.EqlGetRequest{
+		DocumentID:               id,
+		WaitForCompletionTimeout: waitForCompletionTimeout,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EQL search: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var eqlResp EQLResponse
+	if err := json.NewDecoder(res.Body).Decode(&eqlResp); err != nil {
+		return nil, fmt.Errorf("failed to parse EQL response: %w", err)
+	}
+
+	return &eqlResp, nil
+}
+
+// SQL executes a SQL query, or fetches the next page of a previous query
+// when only the cursor is set.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: SQL query with optional fetch size, filter and time zone, or a cursor
+//
+// Returns:
+//   - *SQLResponse: Columns, rows and the cursor for the next page
+//   - error: Any error that occurred during the query
+func (c *ESClient) SQL(ctx context.Context, req *SQLRequest) (*SQLResponse, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize SQL request: %w", err)
+	}
+
+	sqlReq := esapi.SQLQueryRequest{
+		Body:   &bodyReader{data: bodyBytes},
+		Format: "json",
+	}
+
+	res, err := sqlReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("SQL query failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var sqlResp SQLResponse
+	if err := json.NewDecoder(res.Body).Decode(&sqlResp); err != nil {
+		return nil, fmt.Errorf("failed to parse SQL response: %w", err)
+	}
+
+	return &sqlResp, nil
+}
+
+// SQLTranslate translates a SQL query into the equivalent search request.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: SQL query with optional fetch size, filter and time zone
+//
+// Returns:
+//   - map[string]interface{}: Search request body in Query DSL
+//   - error: Any error that occurred during the translation
+func (c *ESClient) SQLTranslate(ctx context.Context, req *SQLRequest) (map[string]interface{}, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize SQL request: %w", err)
+	}
+
+	translateReq := esapi.SQLTranslateRequest{
+		Body: &bodyReader{data: bodyBytes},
+	}
+
+	res, err := translateReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("SQL translate failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var translation map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&translation); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return translation, nil
+}
+
+// ESQL executes an ES|QL query. ES|QL requires Elasticsearch 8.11 or later.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: ES|QL query with optional parameters and filter
+//
+// Returns:
+//   - *ESQLResponse: Typed columns and row values
+//   - error: Any error that occurred during the query
+func (c *ESClient) ESQL(ctx context.Context, req *ESQLRequest) (*ESQLResponse, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize ES|QL request: %w", err)
+	}
+
+	esqlReq := esapi.EsqlQueryRequest{
+		Body:   &bodyReader{data: bodyBytes},
+		Format: "json",
+	}
+
+	res, err := esqlReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("ES|QL query failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var esqlResp ESQLResponse
+	if err := json.NewDecoder(res.Body).Decode(&esqlResp); err != nil {
+		return nil, fmt.Errorf("failed to parse ES|QL response: %w", err)
+	}
+
+	return &esqlResp, nil
+}
+
+// Bulk performs multiple operations in a single request.
+// This is more efficient than individual operations for large datasets.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - operations: List of bulk operations to perform
+//   - opts: Optional request-level parameters (may be nil)
+//
+// Returns:
+//   - *BulkResponse: Results of all bulk operations
+//   - error: Any error that occurred during bulk operation
+func (c *ESClient) Bulk(ctx context.Context, operations []BulkOperation, opts *BulkOptions) (*BulkResponse, error) {
+	body, err := encodeBulkBody(operations)
+	if err != nil {
+		return nil, err
+	}
+
+	req := esapi.BulkRequest{
+		Body:    body,
+		Refresh: c.config.BulkRefresh,
+	}
+	if opts != nil {
+		req.Routing = opts.Routing
+		req.Pipeline = opts.Pipeline
+		if opts.Refresh != "" {
+			req.Refresh = opts.Refresh
+		}
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("bulk operation failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	var bulkResp BulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&bulkResp); err != nil {
+		return nil, fmt.Errorf("failed to parse bulk response: %w", err)
+	}
+
+	return &bulkResp, nil
+}
+
+// encodeBulkBody builds the NDJSON body of a bulk request
+func encodeBulkBody(operations []BulkOperation) (*bytes.Buffer, error) {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, op := range operations {
+		// Mapping types were removed in Elasticsearch 8
+		if op.Type != "" {
+			return nil, fmt.Errorf("bulk operation type '%s' is not supported, mapping types were removed in Elasticsearch 8", op.Type)
+		}
+
+		meta := bulkActionMeta{
+			Index:         op.Index,
+			ID:            op.ID,
+			Routing:       op.Routing,
+			RequireAlias:  op.RequireAlias,
+			IfSeqNo:       op.IfSeqNo,
+			IfPrimaryTerm: op.IfPrimaryTerm,
+		}
+
+		// The payload line depends on the operation; delete has none
+		var payload interface{}
+		switch op.Operation {
+		case "index", "create":
+			meta.Pipeline = op.Pipeline
+			payload = op.Body
+		case "update":
+			meta.RetryOnConflict = op.RetryOnConflict
+			payload = &UpdateRequest{
+				Doc:            op.Body,
+				Script:         op.Script,
+				Upsert:         op.Upsert,
+				DocAsUpsert:    op.DocAsUpsert,
+				ScriptedUpsert: op.ScriptedUpsert,
+			}
+		case "delete":
+		default:
+			return nil, fmt.Errorf("unsupported bulk operation: %s", op.Operation)
+		}
+
+		// Encode terminates each line with a newline
+		if err := encoder.Encode(map[string]bulkActionMeta{op.Operation: meta}); err != nil {
+			return nil, fmt.Errorf("failed to serialize bulk operation: %w", err)
+		}
+		if payload != nil {
+			if err := encoder.Encode(payload); err != nil {
+				return nil, fmt.Errorf("failed to serialize bulk operation source: %w", err)
+			}
+		}
+	}
+
+	return &body, nil
+}
+
+// documentRefresh returns the refresh policy for a single-document write,
+// falling back to the configured default
+func (c *ESClient) documentRefresh(opts *DocumentOptions) string {
+	if opts != nil && opts.Refresh != "" {
+		return opts.Refresh
+	}
+	return c.config.DocumentRefresh
+}
+
+// newResponseError builds a *ResponseError from an error response.
+// If the body is not a structured Elasticsearch error, the raw body is used as reason.
+func newResponseError(res *esapi.Response) error {
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("elasticsearch error: [%d] failed to read response body: %w", res.StatusCode, err)
+	}
+
+	var body struct {
+		Error  json.RawMessage `json:"error"`
+		Status int             `json:"status"`
+	}
+	respErr := &ResponseError{StatusCode: res.StatusCode}
+	if err := json.Unmarshal(data, &body); err != nil || len(body.Error) == 0 {
+		respErr.Reason = string(data)
+		return respErr
+	}
+
+	// The error is usually an object, but some APIs return a plain string
+	if err := json.Unmarshal(body.Error, respErr); err != nil {
+		var reason string
+		if json.Unmarshal(body.Error, &reason) != nil {
+			reason = string(body.Error)
+		}
+		respErr.Reason = reason
+	}
+	respErr.StatusCode = res.StatusCode
+
+	return respErr
+}
+
+// Reindex starts copying documents from the source to the destination index
+// as a background task and returns immediately.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: Reindex request with source, destination and optional transformation
+//
+// Returns:
+//   - string: ID of the task to poll with GetTask
+//   - error: Any error that occurred while starting the reindex
+func (c *ESClient) Reindex(ctx context.Context, req *ReindexRequest) (string, error) {
+	source := map[string]interface{}{
+		"index": req.SourceIndex,
+	}
+	if req.Query != nil {
+		source["query"] = req.Query
+	}
+
+	dest := map[string]interface{}{
+		"index": req.DestIndex,
+	}
+	if req.Pipeline != "" {
+		dest["pipeline"] = req.Pipeline
+	}
+
+	reindexBody := map[string]interface{}{
+		"source": source,
+		"dest":   dest,
+	}
+	if req.Script != nil {
+		reindexBody["script"] = req.Script
+	}
+	if req.MaxDocs > 0 {
+		reindexBody["max_docs"] = req.MaxDocs
+	}
+	if req.Conflicts != "" {
+		reindexBody["conflicts"] = req.Conflicts
+	}
+
+	bodyBytes, err := json.Marshal(reindexBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize reindex request: %w", err)
+	}
+
+	waitForCompletion := false
+	esReq := esapi.ReindexRequest{
+		Body:              &bodyReader{data: bodyBytes},
+		Slices:            req.Slices,
+		WaitForCompletion: &waitForCompletion,
+	}
+	if req.Refresh {
+		esReq.Refresh = &req.Refresh
+	}
+
+	res, err := esReq.Do(ctx, c.client)
+	if err != nil {
+		return "", fmt.Errorf("reindex failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", newResponseError(res)
+	}
+
+	var taskResp struct {
+		Task string `json:"task"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&taskResp); err != nil {
+		return "", fmt.Errorf("failed to parse reindex response: %w", err)
+	}
+
+	return taskResp.Task, nil
+}
+
+// UpdateByQuery updates all documents matching a query, typically with a script.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: Request with target indices, query, script and execution options
+//
+// Returns:
+//   - *ByQueryResponse: Affected counts, or only the task ID when run asynchronously
+//   - error: Any error that occurred during the operation
+func (c *ESClient) UpdateByQuery(ctx context.Context, req *ByQueryRequest) (*ByQueryResponse, error) {
+	updateBody := make(map[string]interface{})
+	if req.Query != nil {
+		updateBody["query"] = req.Query
+	}
+	if req.Script != nil {
+		updateBody["script"] = req.Script
+	}
+
+	bodyBytes, err := json.Marshal(updateBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize update by query request: %w", err)
+	}
+
+	esReq := esapi.UpdateByQueryRequest{
+		Index:             req.Index,
+		Body:              &bodyReader{data: bodyBytes},
+		Conflicts:         req.Conflicts,
+		Slices:            req.Slices,
+		RequestsPerSecond: req.RequestsPerSecond,
+		WaitForCompletion: &req.WaitForCompletion,
+	}
+	if req.MaxDocs > 0 {
+		esReq.MaxDocs = &req.MaxDocs
+	}
+	if req.Refresh {
+		esReq.Refresh = &req.Refresh
+	}
+
+	res, err := esReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("update by query failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var byQueryResp ByQueryResponse
+	if err := json.NewDecoder(res.Body).Decode(&byQueryResp); err != nil {
+		return nil, fmt.Errorf("failed to parse update by query response: %w", err)
+	}
+
+	return &byQueryResp, nil
+}
+
+// DeleteByQuery deletes all documents matching a query.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: Request with target indices, query and execution options
+//
+// Returns:
+//   - *ByQueryResponse: Affected counts, or only the task ID when run asynchronously
+//   - error: Any error that occurred during the operation
+func (c *ESClient) DeleteByQuery(ctx context.Context, req *ByQueryRequest) (*ByQueryResponse, error) {
+	bodyBytes, err := json.Marshal(map[string]interface{}{"query": req.Query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize delete by query request: %w", err)
+	}
+
+	esReq := esapi.DeleteByQueryRequest{
+		Index:             req.Index,
+		Body:              &bodyReader{data: bodyBytes},
+		Conflicts:         req.Conflicts,
+		Slices:            req.Slices,
+		RequestsPerSecond: req.RequestsPerSecond,
+		WaitForCompletion: &req.WaitForCompletion,
+	}
+	if req.MaxDocs > 0 {
+		esReq.MaxDocs = &req.MaxDocs
+	}
+	if req.Refresh {
+		esReq.Refresh = &req.Refresh
+	}
+
+	res, err := esReq.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("delete by query failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newResponseError(res)
+	}
+
+	var byQueryResp ByQueryResponse
+	if err := json.NewDecoder(res.Body).Decode(&byQueryResp); err != nil {
+		return nil, fmt.Errorf("failed to parse delete by query response: %w", err)
+	}
+
+	return &byQueryResp, nil
+}
+
+// GetTask retrieves the status of a task, including progress counters and,
+// once completed, its final response.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - taskID: Task ID in the form node_id:task_number
+//
+// Returns:
+//   - *TaskResponse: Task status and result
+//   - error: Any error that occurred during the operation
+func (c *ESClient) GetTask(ctx context.Context, taskID string) (*TaskResponse, error) {
+	req := esapi.TasksGetRequest{
+		TaskID: taskID,
+	}
+
+	res, err := req.Do(ctx, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, fmt.Errorf("task not found")
+		}
+		return nil, fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	var taskResp TaskResponse
+	if err := json.NewDecoder(res.Body).Decode(&taskResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &taskResp, nil
 }
 
 // Close gracefully closes the Elasticsearch client connection.
@@ -0,0 +1,448 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AeaZer/mcp-elasticsearch/config"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// recordedRequest is the last request received by the cluster of newTestClient
+type recordedRequest struct {
+	method string
+	path   string
+	query  url.Values
+	body   map[string]interface{}
+}
+
+// newTestClient returns a client for a fake cluster that answers every
+// request with status and body, and the request it received last
+func newTestClient(t *testing.T, status int, body string) (*ESClient, *recordedRequest) {
+	t.Helper()
+
+	recorded := &recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorded.method = r.Method
+		recorded.path = r.URL.Path
+		recorded.query = r.URL.Query()
+		recorded.body = nil
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &recorded.body); err != nil {
+				t.Errorf("request body is not a JSON object: %s", data)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&config.ElasticsearchConfig{Addresses: []string{server.URL}}, "8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.(*ESClient), recorded
+}
+
+// mustJSON decodes a JSON object, so that expectations compare like decoded request bodies
+func mustJSON(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var value map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestSearchAggregations(t *testing.T) {
+	client, recorded := newTestClient(t, http.StatusOK, `{
+		"took": 2,
+		"hits": {"total": {"value": 3, "relation": "eq"}, "hits": []},
+		"aggregations": {"by_tag": {"buckets": [{"key": "go", "doc_count": 3}]}}
+	}`)
+
+	aggs := map[string]interface{}{"by_tag": map[string]interface{}{"terms": map[string]interface{}{"field": "tag"}}}
+	result, err := client.Search(context.Background(), &SearchRequest{
+		Index: "docs",
+		Query: map[string]interface{}{"match_all": map[string]interface{}{}},
+		Aggs:  aggs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recorded.path != "/docs/_search" {
+		t.Errorf("path = %s, want /docs/_search", recorded.path)
+	}
+	if got := recorded.query.Get("size"); got != "0" {
+		t.Errorf("size = %q, want 0 for an aggregation-only request", got)
+	}
+	want := mustJSON(t, `{"query": {"match_all": {}}, "aggs": {"by_tag": {"terms": {"field": "tag"}}}}`)
+	if !reflect.DeepEqual(recorded.body, want) {
+		t.Errorf("body = %v, want %v", recorded.body, want)
+	}
+
+	buckets := result.Aggregations["by_tag"].(map[string]interface{})["buckets"].([]interface{})
+	if len(buckets) != 1 || buckets[0].(map[string]interface{})["key"] != "go" {
+		t.Errorf("aggregations = %v", result.Aggregations)
+	}
+}
+
+func TestSearchHitDetails(t *testing.T) {
+	client, recorded := newTestClient(t, http.StatusOK, `{
+		"hits": {"hits": [{
+			"_index": "docs",
+			"_id": "1",
+			"_score": 1.5,
+			"_source": {"title": "Go in action"},
+			"highlight": {"title": ["<em>Go</em> in action"]},
+			"_explanation": {"value": 1.5, "description": "weight(title:go)"},
+			"fields": {"published": ["2024-01-01"]},
+			"matched_queries": {"by_title": 1.5}
+		}]}
+	}`)
+
+	result, err := client.Search(context.Background(), &SearchRequest{
+		Index:                    "docs",
+		Query:                    map[string]interface{}{"match": map[string]interface{}{"title": map[string]interface{}{"query": "go", "_name": "by_title"}}},
+		Highlight:                map[string]interface{}{"fields": map[string]interface{}{"title": map[string]interface{}{}}},
+		Fields:                   []interface{}{map[string]interface{}{"field": "published", "format": "yyyy-MM-dd"}},
+		Explain:                  true,
+		IncludeNamedQueriesScore: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"highlight", "fields", "explain"} {
+		if _, ok := recorded.body[key]; !ok {
+			t.Errorf("body has no %q: %v", key, recorded.body)
+		}
+	}
+	if got := recorded.query.Get("include_named_queries_score"); got != "true" {
+		t.Errorf("include_named_queries_score = %q, want true", got)
+	}
+
+	if len(result.Hits.Hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(result.Hits.Hits))
+	}
+	hit := result.Hits.Hits[0]
+	if got := hit.Highlight["title"]; len(got) != 1 || got[0] != "<em>Go</em> in action" {
+		t.Errorf("highlight = %v", hit.Highlight)
+	}
+	if hit.Explanation["description"] != "weight(title:go)" {
+		t.Errorf("explanation = %v", hit.Explanation)
+	}
+	if !reflect.DeepEqual(hit.Fields["published"], []interface{}{"2024-01-01"}) {
+		t.Errorf("fields = %v", hit.Fields)
+	}
+	if !reflect.DeepEqual(hit.MatchedQueries, map[string]interface{}{"by_title": 1.5}) {
+		t.Errorf("matched queries = %v", hit.MatchedQueries)
+	}
+}
+
+func TestSearchKnnWithRank(t *testing.T) {
+	client, recorded := newTestClient(t, http.StatusOK, `{
+		"hits": {"hits": [
+			{"_index": "docs", "_id": "7", "_rank": 1},
+			{"_index": "docs", "_id": "3", "_rank": 2}
+		]}
+	}`)
+
+	knn := map[string]interface{}{"field": "embedding", "query_vector": []interface{}{0.5, 0.25}, "k": 10, "num_candidates": 50}
+	result, err := client.Search(context.Background(), &SearchRequest{
+		Index: "docs",
+		Size:  2,
+		Query: map[string]interface{}{"match": map[string]interface{}{"title": "go"}},
+		Knn:   knn,
+		Rank:  map[string]interface{}{"rrf": map[string]interface{}{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := mustJSON(t, `{
+		"query": {"match": {"title": "go"}},
+		"knn": {"field": "embedding", "query_vector": [0.5, 0.25], "k": 10, "num_candidates": 50},
+		"rank": {"rrf": {}}
+	}`)
+	if !reflect.DeepEqual(recorded.body, want) {
+		t.Errorf("body = %v, want %v", recorded.body, want)
+	}
+
+	var ranks []int
+	for _, hit := range result.Hits.Hits {
+		ranks = append(ranks, hit.Rank)
+	}
+	if !reflect.DeepEqual(ranks, []int{1, 2}) {
+		t.Errorf("ranks = %v, want [1 2]", ranks)
+	}
+}
+
+func TestSearchPagination(t *testing.T) {
+	pit := &PointInTime{ID: "pit-1", KeepAlive: "1m"}
+	tests := []struct {
+		name     string
+		req      SearchRequest
+		wantPath string
+		wantFrom string
+		wantBody string
+	}{
+		{
+			name:     "offset",
+			req:      SearchRequest{Index: "docs", Size: 10, From: 20},
+			wantPath: "/docs/_search",
+			wantFrom: "20",
+			wantBody: `{}`,
+		},
+		{
+			name:     "first page in a point in time",
+			req:      SearchRequest{Index: "docs", Size: 10, PIT: pit},
+			wantPath: "/_search",
+			wantFrom: "0",
+			wantBody: `{"pit": {"id": "pit-1", "keep_alive": "1m"}}`,
+		},
+		{
+			name:     "next page in a point in time",
+			req:      SearchRequest{Index: "docs", Size: 10, From: 20, PIT: pit, SearchAfter: []interface{}{1700000000000, "doc-9"}},
+			wantPath: "/_search",
+			wantBody: `{"pit": {"id": "pit-1", "keep_alive": "1m"}, "search_after": [1700000000000, "doc-9"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, recorded := newTestClient(t, http.StatusOK, `{"hits": {"hits": []}}`)
+			if _, err := client.Search(context.Background(), &tt.req); err != nil {
+				t.Fatal(err)
+			}
+
+			if recorded.path != tt.wantPath {
+				t.Errorf("path = %s, want %s", recorded.path, tt.wantPath)
+			}
+			if got := recorded.query.Get("from"); got != tt.wantFrom {
+				t.Errorf("from = %q, want %q", got, tt.wantFrom)
+			}
+			if want := mustJSON(t, tt.wantBody); !reflect.DeepEqual(recorded.body, want) {
+				t.Errorf("body = %v, want %v", recorded.body, want)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	client, recorded := newTestClient(t, http.StatusOK, `{"count": 42, "_shards": {"total": 1, "successful": 1}}`)
+
+	query := map[string]interface{}{"term": map[string]interface{}{"status": "active"}}
+	result, err := client.Count(context.Background(), "docs", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != 42 {
+		t.Errorf("count = %d, want 42", result.Count)
+	}
+	if recorded.path != "/docs/_count" {
+		t.Errorf("path = %s, want /docs/_count", recorded.path)
+	}
+	if want := mustJSON(t, `{"query": {"term": {"status": "active"}}}`); !reflect.DeepEqual(recorded.body, want) {
+		t.Errorf("body = %v, want %v", recorded.body, want)
+	}
+
+	// Without index and query all documents of all indices are counted
+	if _, err := client.Count(context.Background(), "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if recorded.path != "/_count" || recorded.body != nil {
+		t.Errorf("path = %s, body = %v, want /_count without body", recorded.path, recorded.body)
+	}
+}
+
+func TestNewResponseError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   ResponseError
+	}{
+		{
+			name:   "structured error",
+			status: http.StatusBadRequest,
+			body: `{"status": 400, "error": {
+				"type": "illegal_argument_exception",
+				"reason": "mapper [title] cannot be changed from type [text] to [keyword]",
+				"root_cause": [{"type": "illegal_argument_exception", "reason": "mapper [title] cannot be changed"}]
+			}}`,
+			want: ResponseError{
+				StatusCode: http.StatusBadRequest,
+				Type:       "illegal_argument_exception",
+				Reason:     "mapper [title] cannot be changed from type [text] to [keyword]",
+				RootCause:  []ErrorCause{{Type: "illegal_argument_exception", Reason: "mapper [title] cannot be changed"}},
+			},
+		},
+		{
+			name:   "string error",
+			status: http.StatusNotFound,
+			body:   `{"status": 404, "error": "alias [logs] missing"}`,
+			want:   ResponseError{StatusCode: http.StatusNotFound, Reason: "alias [logs] missing"},
+		},
+		{
+			name:   "unstructured body",
+			status: http.StatusBadGateway,
+			body:   "upstream unavailable",
+			want:   ResponseError{StatusCode: http.StatusBadGateway, Reason: "upstream unavailable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newResponseError(&esapi.Response{
+				StatusCode: tt.status,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			})
+
+			var respErr *ResponseError
+			if !errors.As(err, &respErr) {
+				t.Fatalf("error = %v, want a *ResponseError", err)
+			}
+			if !reflect.DeepEqual(*respErr, tt.want) {
+				t.Errorf("error = %+v, want %+v", *respErr, tt.want)
+			}
+		})
+	}
+}
+
+func TestPutMappingConflict(t *testing.T) {
+	client, recorded := newTestClient(t, http.StatusBadRequest, `{"status": 400, "error": {"type": "illegal_argument_exception", "reason": "mapper [title] cannot be changed"}}`)
+
+	err := client.PutMapping(context.Background(), "docs", map[string]interface{}{
+		"properties": map[string]interface{}{"title": map[string]interface{}{"type": "keyword"}},
+	})
+
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.Type != "illegal_argument_exception" {
+		t.Fatalf("error = %v, want an illegal_argument_exception", err)
+	}
+	if recorded.method != http.MethodPut || recorded.path != "/docs/_mapping" {
+		t.Errorf("request = %s %s, want PUT /docs/_mapping", recorded.method, recorded.path)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	client, recorded := newTestClient(t, http.StatusOK, `{
+		"columns": [{"name": "author", "type": "text"}, {"name": "books", "type": "long"}],
+		"rows": [["Tolkien", 4]],
+		"cursor": "c2Vjb25k"
+	}`)
+
+	result, err := client.SQL(context.Background(), &SQLRequest{
+		Query:     "SELECT author, COUNT(*) AS books FROM library GROUP BY author",
+		FetchSize: 1,
+		TimeZone:  "Europe/Berlin",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recorded.path != "/_sql" || recorded.query.Get("format") != "json" {
+		t.Errorf("request = %s?%s, want /_sql?format=json", recorded.path, recorded.query.Encode())
+	}
+	want := mustJSON(t, `{"query": "SELECT author, COUNT(*) AS books FROM library GROUP BY author", "fetch_size": 1, "time_zone": "Europe/Berlin"}`)
+	if !reflect.DeepEqual(recorded.body, want) {
+		t.Errorf("body = %v, want %v", recorded.body, want)
+	}
+
+	if len(result.Columns) != 2 || result.Columns[1] != (SQLColumn{Name: "books", Type: "long"}) {
+		t.Errorf("columns = %+v", result.Columns)
+	}
+	if len(result.Rows) != 1 || result.Cursor != "c2Vjb25k" {
+		t.Errorf("rows = %v, cursor = %q", result.Rows, result.Cursor)
+	}
+}
+
+func TestEncodeBulkBody(t *testing.T) {
+	seqNo, primaryTerm := 5, 1
+	operations := []BulkOperation{
+		{
+			Operation:    "index",
+			Index:        "docs",
+			ID:           "1",
+			Body:         map[string]interface{}{"title": "a"},
+			Routing:      "r",
+			Pipeline:     "p",
+			RequireAlias: true,
+		},
+		{
+			Operation:       "update",
+			Index:           "docs",
+			ID:              "2",
+			Body:            map[string]interface{}{"title": "b"},
+			DocAsUpsert:     true,
+			RetryOnConflict: 3,
+			IfSeqNo:         &seqNo,
+			IfPrimaryTerm:   &primaryTerm,
+		},
+		{
+			Operation:      "update",
+			Index:          "docs",
+			ID:             "3",
+			Script:         map[string]interface{}{"source": "ctx._source.n += 1"},
+			Upsert:         map[string]interface{}{"n": 1},
+			ScriptedUpsert: true,
+			// Pipelines only apply to index and create
+			Pipeline: "p",
+		},
+		{
+			Operation: "delete",
+			Index:     "docs",
+			ID:        "4",
+			Routing:   "r",
+		},
+	}
+
+	want := strings.Join([]string{
+		`{"index":{"_index":"docs","_id":"1","routing":"r","pipeline":"p","require_alias":true}}`,
+		`{"title":"a"}`,
+		`{"update":{"_index":"docs","_id":"2","if_seq_no":5,"if_primary_term":1,"retry_on_conflict":3}}`,
+		`{"doc":{"title":"b"},"doc_as_upsert":true}`,
+		`{"update":{"_index":"docs","_id":"3"}}`,
+		`{"script":{"source":"ctx._source.n += 1"},"upsert":{"n":1},"scripted_upsert":true}`,
+		`{"delete":{"_index":"docs","_id":"4","routing":"r"}}`,
+	}, "\n") + "\n"
+
+	body, err := encodeBulkBody(operations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := body.String(); got != want {
+		t.Errorf("body =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestEncodeBulkBodyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		op   BulkOperation
+	}{
+		{name: "mapping type", op: BulkOperation{Operation: "index", Index: "docs", Type: "_doc", Body: map[string]interface{}{}}},
+		{name: "unknown operation", op: BulkOperation{Operation: "upsert", Index: "docs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := encodeBulkBody([]BulkOperation{tt.op}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
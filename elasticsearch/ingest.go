@@ -0,0 +1,304 @@
+package elasticsearch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+)
+
+// maxIngestFailures limits how many per-item failures are kept in a BulkIngestResponse
+const maxIngestFailures = 100
+
+// maxNDJSONLineSize is the largest NDJSON line (i.e. document) accepted by BulkIngest
+const maxNDJSONLineSize = 64 * 1024 * 1024
+
+// BulkIngest streams documents from req.Reader into an index through a
+// BulkIndexer. Documents are read one at a time, so payloads much larger than
+// memory can be ingested. Records that cannot be parsed are reported as
+// failures and skipped, except for JSON arrays which cannot be resumed.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - req: Ingestion request with the payload reader, format and indexer settings
+//
+// Returns:
+//   - *BulkIngestResponse: Counters, throughput and per-item failures
+//   - error: Any error that stopped the ingestion
+func (c *ESClient) BulkIngest(ctx context.Context, req *BulkIngestRequest) (*BulkIngestResponse, error) {
+	refresh := req.Refresh
+	if refresh == "" {
+		refresh = c.config.BulkRefresh
+	}
+
+	var (
+		mu       sync.Mutex
+		response BulkIngestResponse
+	)
+	recordFailure := func(failure BulkIngestFailure) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(response.Failures) < maxIngestFailures {
+			response.Failures = append(response.Failures, failure)
+		} else {
+			response.FailuresTruncated = true
+		}
+	}
+
+	indexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Client:        c.client,
+		Index:         req.Index,
+		NumWorkers:    req.Workers,
+		FlushBytes:    req.FlushBytes,
+		FlushInterval: req.FlushInterval,
+		Pipeline:      req.Pipeline,
+		Refresh:       refresh,
+		OnError: func(ctx context.Context, err error) {
+			recordFailure(BulkIngestFailure{Reason: err.Error()})
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk indexer: %w", err)
+	}
+
+	action := req.OpType
+	if action == "" {
+		action = "index"
+	}
+
+	counter := &countingReader{reader: req.Reader}
+	start := time.Now()
+
+	var invalid uint64
+	readErr := readIngestRecords(counter, req.Format, func(position int, doc []byte, err error) error {
+		var id string
+		if err == nil {
+			id, err = ingestDocumentID(doc, req.IDField)
+		}
+		if err != nil {
+			invalid++
+			recordFailure(BulkIngestFailure{Position: position, Type: "parse_exception", Reason: err.Error()})
+			return nil
+		}
+
+		return indexer.Add(ctx, esutil.BulkIndexerItem{
+			Action:     action,
+			DocumentID: id,
+			Body:       bytes.NewReader(doc),
+			OnFailure: func(ctx context.Context, item esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem, err error) {
+				failure := BulkIngestFailure{
+					Position: position,
+					ID:       item.DocumentID,
+					Status:   res.Status,
+					Type:     res.Error.Type,
+					Reason:   res.Error.Reason,
+				}
+				if err != nil {
+					failure.Reason = err.Error()
+				}
+				recordFailure(failure)
+			},
+		})
+	})
+
+	// Always flush what was added so far, even if reading stopped early
+	if err := indexer.Close(ctx); err != nil && readErr == nil {
+		readErr = fmt.Errorf("failed to flush bulk indexer: %w", err)
+	}
+
+	duration := time.Since(start)
+	stats := indexer.Stats()
+
+	response.Added = stats.NumAdded
+	response.Indexed = stats.NumIndexed
+	response.Created = stats.NumCreated
+	response.Failed = stats.NumFailed + invalid
+	response.Requests = stats.NumRequests
+	response.BytesRead = counter.count
+	response.DurationMillis = duration.Milliseconds()
+	if seconds := duration.Seconds(); seconds > 0 {
+		response.DocsPerSecond = float64(stats.NumFlushed) / seconds
+		response.MBPerSecond = float64(counter.count) / (1024 * 1024) / seconds
+	}
+
+	if readErr != nil {
+		return &response, readErr
+	}
+	return &response, nil
+}
+
+// ingestRecordFunc receives a record with its 1-based position and the document
+// as JSON. err is set instead of doc for records that could not be read;
+// reading continues with the next record unless the function returns an error.
+type ingestRecordFunc func(position int, doc []byte, err error) error
+
+// readIngestRecords reads documents of the given format from r and calls fn for each record
+func readIngestRecords(r io.Reader, format string, fn ingestRecordFunc) error {
+	switch format {
+	case "ndjson", "":
+		return readNDJSONRecords(r, fn)
+	case "json":
+		return readJSONArrayRecords(r, fn)
+	case "csv":
+		return readCSVRecords(r, fn)
+	default:
+		return fmt.Errorf("unsupported format: %s, supported formats: ndjson, json, csv", format)
+	}
+}
+
+// readNDJSONRecords reads one JSON document per line, skipping blank lines
+func readNDJSONRecords(r io.Reader, fn ingestRecordFunc) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		doc := bytes.TrimSpace(scanner.Bytes())
+		if len(doc) == 0 {
+			continue
+		}
+
+		// The scanner reuses its buffer, but the indexer reads the body later
+		if err := fn(line, append([]byte(nil), doc...), nil); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read NDJSON line %d: %w", line+1, err)
+	}
+	return nil
+}
+
+// readJSONArrayRecords reads the elements of a top-level JSON array one at a time
+func readJSONArrayRecords(r io.Reader, fn ingestRecordFunc) error {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read JSON array: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("failed to read JSON array: payload must be an array of documents")
+	}
+
+	position := 0
+	for decoder.More() {
+		position++
+		var doc json.RawMessage
+		if err := decoder.Decode(&doc); err != nil {
+			return fmt.Errorf("failed to read JSON array element %d: %w", position, err)
+		}
+		if err := fn(position, doc, nil); err != nil {
+			return err
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("failed to read JSON array: %w", err)
+	}
+	return nil
+}
+
+// readCSVRecords reads CSV rows as documents keyed by the header row.
+// Values are kept as strings and coerced by the index mappings. Malformed
+// rows, e.g. with a wrong number of fields, are reported and skipped.
+func readCSVRecords(r io.Reader, fn ingestRecordFunc) error {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	header = append([]string(nil), header...)
+
+	// Row lengths are checked below so that a ragged row does not stop reading
+	reader.FieldsPerRecord = -1
+
+	position := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		position++
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if err := fn(position, nil, fmt.Errorf("invalid CSV row: %w", err)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read CSV row %d: %w", position, err)
+		}
+
+		if len(record) != len(header) {
+			rowErr := fmt.Errorf("invalid CSV row: expected %d fields, got %d", len(header), len(record))
+			if err := fn(position, nil, rowErr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = record[i]
+		}
+
+		doc, err := json.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("failed to serialize CSV row %d: %w", position, err)
+		}
+		if err := fn(position, doc, nil); err != nil {
+			return err
+		}
+	}
+}
+
+// ingestDocumentID validates that doc is a JSON object and returns the value
+// of idField as document ID, or an empty string if idField is not set
+func ingestDocumentID(doc []byte, idField string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return "", fmt.Errorf("invalid document: %w", err)
+	}
+	if idField == "" {
+		return "", nil
+	}
+
+	raw, ok := fields[idField]
+	if !ok {
+		return "", fmt.Errorf("document has no '%s' field", idField)
+	}
+
+	// Use strings as is and any other scalar (e.g. numbers) verbatim
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		id = string(raw)
+	}
+	return id, nil
+}
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.reader.Read(p)
+	cr.count += int64(n)
+	return n, err
+}
@@ -0,0 +1,163 @@
+package elasticsearch
+
+import (
+	"strings"
+	"testing"
+)
+
+// ingestRecord is a record as passed to an ingestRecordFunc
+type ingestRecord struct {
+	position int
+	doc      string
+	invalid  bool
+}
+
+// collectIngestRecords reads input with readIngestRecords and returns all records
+func collectIngestRecords(format, input string) ([]ingestRecord, error) {
+	var records []ingestRecord
+	err := readIngestRecords(strings.NewReader(input), format, func(position int, doc []byte, err error) error {
+		records = append(records, ingestRecord{position: position, doc: string(doc), invalid: err != nil})
+		return nil
+	})
+	return records, err
+}
+
+func TestReadIngestRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    []ingestRecord
+		wantErr bool
+	}{
+		{
+			name:   "ndjson skips blank lines and keeps line numbers",
+			format: "ndjson",
+			input:  "{\"a\":1}\n\n  {\"a\":2}  \n",
+			want:   []ingestRecord{{position: 1, doc: `{"a":1}`}, {position: 3, doc: `{"a":2}`}},
+		},
+		{
+			name:   "ndjson is the default format",
+			format: "",
+			input:  `{"a":1}`,
+			want:   []ingestRecord{{position: 1, doc: `{"a":1}`}},
+		},
+		{
+			name:   "ndjson passes invalid lines on for validation",
+			format: "ndjson",
+			input:  "not json\n{\"a\":2}\n",
+			want:   []ingestRecord{{position: 1, doc: "not json"}, {position: 2, doc: `{"a":2}`}},
+		},
+		{
+			name:   "json array",
+			format: "json",
+			input:  `[{"a":1}, {"a":2}]`,
+			want:   []ingestRecord{{position: 1, doc: `{"a":1}`}, {position: 2, doc: `{"a":2}`}},
+		},
+		{
+			name:    "json payload must be an array",
+			format:  "json",
+			input:   `{"a":1}`,
+			wantErr: true,
+		},
+		{
+			name:    "truncated json array stops after the last complete element",
+			format:  "json",
+			input:   `[{"a":1}, {"a":`,
+			want:    []ingestRecord{{position: 1, doc: `{"a":1}`}},
+			wantErr: true,
+		},
+		{
+			name:   "csv rows are keyed by the header",
+			format: "csv",
+			input:  "name,age\nalice,30\nbob,40\n",
+			want: []ingestRecord{
+				{position: 1, doc: `{"age":"30","name":"alice"}`},
+				{position: 2, doc: `{"age":"40","name":"bob"}`},
+			},
+		},
+		{
+			name:   "csv ragged rows are skipped",
+			format: "csv",
+			input:  "name,age\nalice,30\nbob\ncarol,40,extra\ndave,50\n",
+			want: []ingestRecord{
+				{position: 1, doc: `{"age":"30","name":"alice"}`},
+				{position: 2, invalid: true},
+				{position: 3, invalid: true},
+				{position: 4, doc: `{"age":"50","name":"dave"}`},
+			},
+		},
+		{
+			name:   "csv rows with bare quotes are skipped",
+			format: "csv",
+			input:  "name,age\nal\"ice,30\ndave,50\n",
+			want: []ingestRecord{
+				{position: 1, invalid: true},
+				{position: 2, doc: `{"age":"50","name":"dave"}`},
+			},
+		},
+		{
+			name:   "csv with only a header has no records",
+			format: "csv",
+			input:  "name,age\n",
+		},
+		{
+			name:    "csv without header",
+			format:  "csv",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			format:  "xml",
+			input:   "<doc/>",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := collectIngestRecords(tt.format, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d records %+v, want %d %+v", len(got), got, len(tt.want), tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("record %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIngestDocumentID(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		idField string
+		want    string
+		wantErr bool
+	}{
+		{name: "no id field", doc: `{"id":"a"}`, idField: "", want: ""},
+		{name: "string id", doc: `{"id":"a"}`, idField: "id", want: "a"},
+		{name: "numeric id", doc: `{"id":7}`, idField: "id", want: "7"},
+		{name: "missing id field", doc: `{"name":"a"}`, idField: "id", wantErr: true},
+		{name: "not an object", doc: `[1,2]`, idField: "", wantErr: true},
+		{name: "invalid json", doc: `not json`, idField: "id", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ingestDocumentID([]byte(tt.doc), tt.idField)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("id = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
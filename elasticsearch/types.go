@@ -3,6 +3,9 @@
 package elasticsearch
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
@@ -61,7 +64,144 @@ type IndexInfo struct {
 	PriStoreSize string `json:"pri.store.size"`
 }
 
-// IndexResponse represents the response from document indexing operations
+// AliasInfo contains information about an alias as reported by the cat aliases API
+type AliasInfo struct {
+	Alias         string `json:"alias"`
+	Index         string `json:"index"`
+	Filter        string `json:"filter"`
+	RoutingIndex  string `json:"routing.index"`
+	RoutingSearch string `json:"routing.search"`
+	IsWriteIndex  string `json:"is_write_index"`
+}
+
+// AliasAction represents a single action in an atomic aliases update.
+// Action is one of "add", "remove" or "remove_index".
+type AliasAction struct {
+	Action        string                 `json:"action"`
+	Index         string                 `json:"index"`
+	Alias         string                 `json:"alias,omitempty"`
+	Filter        map[string]interface{} `json:"filter,omitempty"`
+	Routing       string                 `json:"routing,omitempty"`
+	IndexRouting  string                 `json:"index_routing,omitempty"`
+	SearchRouting string                 `json:"search_routing,omitempty"`
+	IsWriteIndex  *bool                  `json:"is_write_index,omitempty"`
+}
+
+// IndexTemplateItem represents a named composable index template
+type IndexTemplateItem struct {
+	Name          string                 `json:"name"`
+	IndexTemplate map[string]interface{} `json:"index_template"`
+}
+
+// ComponentTemplateItem represents a named component template
+type ComponentTemplateItem struct {
+	Name              string                 `json:"name"`
+	ComponentTemplate map[string]interface{} `json:"component_template"`
+}
+
+// DataStream represents a data stream and its backing indices
+type DataStream struct {
+	Name           string `json:"name"`
+	TimestampField struct {
+		Name string `json:"name"`
+	} `json:"timestamp_field"`
+	Indices    []DataStreamIndex `json:"indices"`
+	Generation int               `json:"generation"`
+	Status     string            `json:"status"`
+	Template   string            `json:"template"`
+	IlmPolicy  string            `json:"ilm_policy,omitempty"`
+	Hidden     bool              `json:"hidden"`
+	System     bool              `json:"system"`
+	Replicated bool              `json:"replicated"`
+}
+
+// DataStreamIndex represents a backing index of a data stream
+type DataStreamIndex struct {
+	IndexName string `json:"index_name"`
+	IndexUUID string `json:"index_uuid"`
+	IlmPolicy string `json:"ilm_policy,omitempty"`
+	ManagedBy string `json:"managed_by,omitempty"`
+}
+
+// RolloverResponse represents the response from the rollover API
+type RolloverResponse struct {
+	Acknowledged       bool            `json:"acknowledged"`
+	ShardsAcknowledged bool            `json:"shards_acknowledged"`
+	OldIndex           string          `json:"old_index"`
+	NewIndex           string          `json:"new_index"`
+	RolledOver         bool            `json:"rolled_over"`
+	DryRun             bool            `json:"dry_run"`
+	Conditions         map[string]bool `json:"conditions"`
+}
+
+// LifecyclePolicy represents an ILM policy together with its metadata
+type LifecyclePolicy struct {
+	Version      int                    `json:"version"`
+	ModifiedDate string                 `json:"modified_date"`
+	Policy       map[string]interface{} `json:"policy"`
+	InUseBy      map[string]interface{} `json:"in_use_by,omitempty"`
+}
+
+// IndexLifecycle describes the current ILM phase, action and step of an index,
+// including the failed step and its error details when the index is stuck
+type IndexLifecycle struct {
+	Index                string                 `json:"index"`
+	Managed              bool                   `json:"managed"`
+	Policy               string                 `json:"policy,omitempty"`
+	Phase                string                 `json:"phase,omitempty"`
+	Action               string                 `json:"action,omitempty"`
+	Step                 string                 `json:"step,omitempty"`
+	FailedStep           string                 `json:"failed_step,omitempty"`
+	IsAutoRetryableError bool                   `json:"is_auto_retryable_error,omitempty"`
+	FailedStepRetryCount int                    `json:"failed_step_retry_count,omitempty"`
+	StepInfo             map[string]interface{} `json:"step_info,omitempty"`
+	LifecycleDateMillis  int64                  `json:"lifecycle_date_millis,omitempty"`
+	PhaseTimeMillis      int64                  `json:"phase_time_millis,omitempty"`
+	ActionTimeMillis     int64                  `json:"action_time_millis,omitempty"`
+	StepTimeMillis       int64                  `json:"step_time_millis,omitempty"`
+	PhaseExecution       map[string]interface{} `json:"phase_execution,omitempty"`
+}
+
+// DocumentOptions contains optional per-call parameters for single-document operations.
+// IfSeqNo and IfPrimaryTerm must be used together; Version and VersionType
+// are not supported by updates. OpType and Pipeline only apply to indexing,
+// and only Routing applies to retrieval.
+type DocumentOptions struct {
+	Routing  string `json:"routing,omitempty"`
+	OpType   string `json:"op_type,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
+
+	IfSeqNo       *int   `json:"if_seq_no,omitempty"`
+	IfPrimaryTerm *int   `json:"if_primary_term,omitempty"`
+	Version       *int   `json:"version,omitempty"`
+	VersionType   string `json:"version_type,omitempty"`
+	// Refresh is true, false or wait_for; empty uses the configured default
+	Refresh string `json:"refresh,omitempty"`
+}
+
+// BulkOptions contains optional request-level parameters for bulk requests.
+// Routing and Pipeline are defaults for operations that do not set their own.
+type BulkOptions struct {
+	Routing  string `json:"routing,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
+
+	// Refresh is true, false or wait_for; empty uses the configured default
+	Refresh string `json:"refresh,omitempty"`
+}
+
+// UpdateRequest represents a document update with a partial document or a script,
+// optionally creating the document when it does not exist yet
+type UpdateRequest struct {
+	Doc            map[string]interface{} `json:"doc,omitempty"`
+	Script         map[string]interface{} `json:"script,omitempty"`
+	Upsert         map[string]interface{} `json:"upsert,omitempty"`
+	DocAsUpsert    bool                   `json:"doc_as_upsert,omitempty"`
+	ScriptedUpsert bool                   `json:"scripted_upsert,omitempty"`
+	// RetryOnConflict is sent as a query parameter, not in the body
+	RetryOnConflict int `json:"-"`
+}
+
+// IndexResponse represents the response from document indexing and update operations
 type IndexResponse struct {
 	Index   string `json:"_index"`
 	Type    string `json:"_type"`
@@ -77,15 +217,152 @@ type IndexResponse struct {
 	PrimaryTerm int `json:"_primary_term"`
 }
 
+// FieldCapsResponse represents the response from the field capabilities API.
+// Fields maps each field name to its capabilities per mapped type.
+type FieldCapsResponse struct {
+	Indices []string                              `json:"indices"`
+	Fields  map[string]map[string]FieldCapability `json:"fields"`
+}
+
+// FieldCapability describes how a field of a given type can be used across indices
+type FieldCapability struct {
+	Type                   string   `json:"type"`
+	MetadataField          bool     `json:"metadata_field,omitempty"`
+	Searchable             bool     `json:"searchable"`
+	Aggregatable           bool     `json:"aggregatable"`
+	Indices                []string `json:"indices,omitempty"`
+	NonSearchableIndices   []string `json:"non_searchable_indices,omitempty"`
+	NonAggregatableIndices []string `json:"non_aggregatable_indices,omitempty"`
+}
+
+// ReindexRequest represents a request to copy documents from source indices
+// into a destination index, optionally transforming them on the way
+type ReindexRequest struct {
+	SourceIndex []string               `json:"source_index"`
+	Query       map[string]interface{} `json:"query,omitempty"`
+	DestIndex   string                 `json:"dest_index"`
+	Pipeline    string                 `json:"pipeline,omitempty"`
+	Script      map[string]interface{} `json:"script,omitempty"`
+	MaxDocs     int                    `json:"max_docs,omitempty"`
+	// Slices is the number of parallel slices or "auto"
+	Slices    interface{} `json:"slices,omitempty"`
+	Conflicts string      `json:"conflicts,omitempty"`
+	// Refresh refreshes the destination index once the reindex completes
+	Refresh bool `json:"refresh,omitempty"`
+}
+
+// ByQueryRequest represents an update by query or delete by query request
+type ByQueryRequest struct {
+	Index []string               `json:"index"`
+	Query map[string]interface{} `json:"query,omitempty"`
+	// Script is only used by update by query
+	Script    map[string]interface{} `json:"script,omitempty"`
+	Conflicts string                 `json:"conflicts,omitempty"`
+	MaxDocs   int                    `json:"max_docs,omitempty"`
+	// Slices is the number of parallel slices or "auto"
+	Slices interface{} `json:"slices,omitempty"`
+	// RequestsPerSecond throttles the operation; nil or -1 disables throttling
+	RequestsPerSecond *int `json:"requests_per_second,omitempty"`
+	// WaitForCompletion runs the operation synchronously; otherwise a task ID is returned
+	WaitForCompletion bool `json:"wait_for_completion"`
+	// Refresh refreshes all affected shards once the operation completes
+	Refresh bool `json:"refresh,omitempty"`
+}
+
+// ByQueryResponse represents the response from update by query and delete by query.
+// When the operation runs asynchronously only Task is set.
+type ByQueryResponse struct {
+	Task     string `json:"task,omitempty"`
+	Took     int64  `json:"took,omitempty"`
+	TimedOut bool   `json:"timed_out,omitempty"`
+	ByQueryTaskStatus
+	Failures []map[string]interface{} `json:"failures,omitempty"`
+}
+
+// TaskResponse represents the response from the tasks API.
+// Response is only set once the task has completed.
+type TaskResponse struct {
+	Completed bool                   `json:"completed"`
+	Task      TaskInfo               `json:"task"`
+	Response  map[string]interface{} `json:"response,omitempty"`
+	Error     map[string]interface{} `json:"error,omitempty"`
+}
+
+// TaskInfo describes a running or completed task
+type TaskInfo struct {
+	Node               string `json:"node"`
+	ID                 int64  `json:"id"`
+	Type               string `json:"type"`
+	Action             string `json:"action"`
+	Description        string `json:"description,omitempty"`
+	StartTimeInMillis  int64  `json:"start_time_in_millis"`
+	RunningTimeInNanos int64  `json:"running_time_in_nanos"`
+	Cancellable        bool   `json:"cancellable"`
+	Cancelled          bool   `json:"cancelled,omitempty"`
+	// Status is task specific; see ByQueryStatus for reindex and by query tasks
+	Status json.RawMessage `json:"status,omitempty"`
+}
+
+// ByQueryStatus decodes the status of reindex, update by query and delete by
+// query tasks. It returns nil for other task types or tasks without status.
+func (t *TaskInfo) ByQueryStatus() *ByQueryTaskStatus {
+	switch t.Action {
+	case "indices:data/write/reindex", "indices:data/write/update/byquery", "indices:data/write/delete/byquery":
+	default:
+		return nil
+	}
+
+	var status ByQueryTaskStatus
+	if len(t.Status) == 0 || json.Unmarshal(t.Status, &status) != nil {
+		return nil
+	}
+	return &status
+}
+
+// ByQueryTaskStatus reports the progress of reindex, update by query and
+// delete by query tasks
+type ByQueryTaskStatus struct {
+	Total            int64 `json:"total"`
+	Created          int64 `json:"created"`
+	Updated          int64 `json:"updated"`
+	Deleted          int64 `json:"deleted"`
+	Batches          int64 `json:"batches"`
+	VersionConflicts int64 `json:"version_conflicts"`
+	Noops            int64 `json:"noops"`
+	Retries          struct {
+		Bulk   int64 `json:"bulk"`
+		Search int64 `json:"search"`
+	} `json:"retries"`
+	ThrottledMillis      int64   `json:"throttled_millis"`
+	RequestsPerSecond    float64 `json:"requests_per_second"`
+	ThrottledUntilMillis int64   `json:"throttled_until_millis"`
+}
+
 // GetResponse represents the response from document retrieval operations
 type GetResponse struct {
-	Index   string                 `json:"_index"`
-	Type    string                 `json:"_type"`
-	ID      string                 `json:"_id"`
-	Version int                    `json:"_version"`
-	SeqNo   int                    `json:"_seq_no"`
-	Found   bool                   `json:"found"`
-	Source  map[string]interface{} `json:"_source"`
+	Index       string                 `json:"_index"`
+	Type        string                 `json:"_type"`
+	ID          string                 `json:"_id"`
+	Version     int                    `json:"_version"`
+	SeqNo       int                    `json:"_seq_no"`
+	PrimaryTerm int                    `json:"_primary_term"`
+	Found       bool                   `json:"found"`
+	Source      map[string]interface{} `json:"_source"`
+}
+
+// MGetDoc identifies a single document to retrieve in a multi-get request
+type MGetDoc struct {
+	Index   string      `json:"_index"`
+	ID      string      `json:"_id"`
+	Routing string      `json:"routing,omitempty"`
+	Source  interface{} `json:"_source,omitempty"`
+}
+
+// MGetItem represents the result for a single document of a multi-get request.
+// Error is set when the document could not be retrieved (e.g. missing index).
+type MGetItem struct {
+	GetResponse
+	Error *ErrorCause `json:"error,omitempty"`
 }
 
 // SearchRequest represents a search request to Elasticsearch
@@ -96,12 +373,36 @@ type SearchRequest struct {
 	From   int                    `json:"from,omitempty"`
 	Sort   []interface{}          `json:"sort,omitempty"`
 	Source interface{}            `json:"_source,omitempty"`
+	Aggs   map[string]interface{} `json:"aggs,omitempty"`
+
+	Highlight map[string]interface{} `json:"highlight,omitempty"`
+	Fields    []interface{}          `json:"fields,omitempty"`
+	Explain   bool                   `json:"explain,omitempty"`
+	// IncludeNamedQueriesScore reports matched_queries as name-to-score maps
+	IncludeNamedQueriesScore bool `json:"include_named_queries_score,omitempty"`
+
+	// SearchAfter holds the sort values of the last hit of the previous page
+	SearchAfter []interface{} `json:"search_after,omitempty"`
+	// PIT runs the search against a point in time instead of the live index
+	PIT *PointInTime `json:"pit,omitempty"`
+
+	// Knn holds one kNN clause or a list of them for approximate vector search.
+	// Combined with Query the scores are summed unless Rank fuses them (e.g. RRF).
+	Knn  interface{}            `json:"knn,omitempty"`
+	Rank map[string]interface{} `json:"rank,omitempty"`
+}
+
+// PointInTime identifies a point in time used for consistent deep pagination
+type PointInTime struct {
+	ID        string `json:"id"`
+	KeepAlive string `json:"keep_alive,omitempty"`
 }
 
 // SearchResponse represents the response from search operations
 type SearchResponse struct {
-	Took     int  `json:"took"`
-	TimedOut bool `json:"timed_out"`
+	PitID    string `json:"pit_id,omitempty"`
+	Took     int    `json:"took"`
+	TimedOut bool   `json:"timed_out"`
 	Shards   struct {
 		Total      int `json:"total"`
 		Successful int `json:"successful"`
@@ -116,6 +417,148 @@ type SearchResponse struct {
 		MaxScore float64     `json:"max_score"`
 		Hits     []SearchHit `json:"hits"`
 	} `json:"hits"`
+	// Aggregations holds the aggregation results keyed by aggregation name.
+	// Bucket aggregations keep their nested sub-aggregations inside each bucket.
+	Aggregations map[string]interface{} `json:"aggregations,omitempty"`
+}
+
+// AsyncSearchOptions controls how long an async search submission or
+// retrieval waits for results and how long the results are kept
+type AsyncSearchOptions struct {
+	// WaitForCompletionTimeout returns partial results if the search is still running after it
+	WaitForCompletionTimeout time.Duration `json:"wait_for_completion_timeout,omitempty"`
+	// KeepAlive is how long the search and its results are kept available
+	KeepAlive time.Duration `json:"keep_alive,omitempty"`
+}
+
+// AsyncSearchResponse represents the state of an async search together with
+// its current, possibly partial, search response
+type AsyncSearchResponse struct {
+	ID                     string          `json:"id,omitempty"`
+	IsPartial              bool            `json:"is_partial"`
+	IsRunning              bool            `json:"is_running"`
+	StartTimeInMillis      int64           `json:"start_time_in_millis"`
+	ExpirationTimeInMillis int64           `json:"expiration_time_in_millis"`
+	CompletionStatus       int             `json:"completion_status,omitempty"`
+	Response               *SearchResponse `json:"response,omitempty"`
+}
+
+// AsyncSearchStatus reports the progress of an async search without its results.
+// CompletionStatus is the HTTP status of the completed search.
+type AsyncSearchStatus struct {
+	ID                     string `json:"id"`
+	IsPartial              bool   `json:"is_partial"`
+	IsRunning              bool   `json:"is_running"`
+	StartTimeInMillis      int64  `json:"start_time_in_millis"`
+	ExpirationTimeInMillis int64  `json:"expiration_time_in_millis"`
+	CompletionStatus       int    `json:"completion_status,omitempty"`
+	Shards                 struct {
+		Total      int `json:"total"`
+		Successful int `json:"successful"`
+		Skipped    int `json:"skipped"`
+		Failed     int `json:"failed"`
+	} `json:"_shards"`
+}
+
+// CountResponse represents the response from the count API
+type CountResponse struct {
+	Count  int64 `json:"count"`
+	Shards struct {
+		Total      int `json:"total"`
+		Successful int `json:"successful"`
+		Skipped    int `json:"skipped"`
+		Failed     int `json:"failed"`
+	} `json:"_shards"`
+}
+
+// SQLRequest represents a SQL query. A request with only Cursor set fetches
+// the next page of a previous query.
+type SQLRequest struct {
+	Query     string                 `json:"query,omitempty"`
+	Cursor    string                 `json:"cursor,omitempty"`
+	FetchSize int                    `json:"fetch_size,omitempty"`
+	Filter    map[string]interface{} `json:"filter,omitempty"`
+	TimeZone  string                 `json:"time_zone,omitempty"`
+}
+
+// SQLResponse represents a page of SQL results. Columns are only returned
+// with the first page; Cursor is empty once all rows have been returned.
+type SQLResponse struct {
+	Columns []SQLColumn     `json:"columns,omitempty"`
+	Rows    [][]interface{} `json:"rows"`
+	Cursor  string          `json:"cursor,omitempty"`
+}
+
+// SQLColumn describes a column of a SQL or ES|QL result
+type SQLColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ESQLRequest represents an ES|QL query. Params are bound to ? placeholders
+// by position, or to ?name placeholders when given as {"name": value} objects.
+type ESQLRequest struct {
+	Query  string                 `json:"query"`
+	Params []interface{}          `json:"params,omitempty"`
+	Filter map[string]interface{} `json:"filter,omitempty"`
+}
+
+// ESQLResponse represents the tabular result of an ES|QL query.
+// Each row in Values holds one value per column, in column order.
+type ESQLResponse struct {
+	Took    int             `json:"took,omitempty"`
+	Columns []SQLColumn     `json:"columns"`
+	Values  [][]interface{} `json:"values"`
+}
+
+// EQLSearchRequest represents an EQL search. Searches that do not complete
+// within WaitForCompletionTimeout continue asynchronously and are kept for KeepAlive.
+type EQLSearchRequest struct {
+	Index              string                 `json:"-"`
+	Query              string                 `json:"query"`
+	TimestampField     string                 `json:"timestamp_field,omitempty"`
+	EventCategoryField string                 `json:"event_category_field,omitempty"`
+	TiebreakerField    string                 `json:"tiebreaker_field,omitempty"`
+	Filter             map[string]interface{} `json:"filter,omitempty"`
+	Size               int                    `json:"size,omitempty"`
+	FetchSize          int                    `json:"fetch_size,omitempty"`
+
+	WaitForCompletionTimeout time.Duration `json:"-"`
+	KeepAlive                time.Duration `json:"-"`
+}
+
+// EQLResponse represents the result of an EQL search. Hits contain either
+// events or sequences, depending on the query. ID is set when the search
+// runs or is stored asynchronously.
+type EQLResponse struct {
+	ID        string `json:"id,omitempty"`
+	IsPartial bool   `json:"is_partial"`
+	IsRunning bool   `json:"is_running"`
+	Took      int    `json:"took"`
+	TimedOut  bool   `json:"timed_out"`
+	Hits      struct {
+		Total struct {
+			Value    int    `json:"value"`
+			Relation string `json:"relation"`
+		} `json:"total"`
+		Events    []EQLEvent    `json:"events,omitempty"`
+		Sequences []EQLSequence `json:"sequences,omitempty"`
+	} `json:"hits"`
+}
+
+// EQLEvent represents a single event matched by an EQL query.
+// Missing is set for placeholder events of sequences with missing events.
+type EQLEvent struct {
+	Index   string                 `json:"_index"`
+	ID      string                 `json:"_id"`
+	Source  map[string]interface{} `json:"_source,omitempty"`
+	Missing bool                   `json:"missing,omitempty"`
+}
+
+// EQLSequence represents an ordered series of events matched by an EQL sequence query
+type EQLSequence struct {
+	JoinKeys []interface{} `json:"join_keys,omitempty"`
+	Events   []EQLEvent    `json:"events"`
 }
 
 // SearchHit represents a single search result
@@ -125,15 +568,105 @@ type SearchHit struct {
 	ID     string                 `json:"_id"`
 	Score  float64                `json:"_score"`
 	Source map[string]interface{} `json:"_source"`
+	Sort   []interface{}          `json:"sort,omitempty"`
+	// Rank is the position of the hit when results are fused with a rank method
+	Rank int `json:"_rank,omitempty"`
+
+	Highlight   map[string][]string    `json:"highlight,omitempty"`
+	Explanation map[string]interface{} `json:"_explanation,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+	InnerHits   map[string]interface{} `json:"inner_hits,omitempty"`
+	// MatchedQueries is a list of query names, or a name-to-score map when
+	// include_named_queries_score is set
+	MatchedQueries interface{} `json:"matched_queries,omitempty"`
 }
 
-// BulkOperation represents a single operation in a bulk request
+// BulkOperation represents a single operation in a bulk request.
+// Operation is one of "index", "create", "update" or "delete". For updates Body
+// is the partial document, sent as "doc", and Script replaces it for scripted
+// updates. Pipeline only applies to index and create, RetryOnConflict and the
+// upsert fields only to update.
 type BulkOperation struct {
-	Operation string                 `json:"operation"`
-	Index     string                 `json:"index"`
-	Type      string                 `json:"type,omitempty"`
-	ID        string                 `json:"id,omitempty"`
-	Body      map[string]interface{} `json:"body,omitempty"`
+	Operation string `json:"operation"`
+	Index     string `json:"index"`
+	// Type is rejected by Bulk, mapping types were removed in Elasticsearch 8
+	Type string                 `json:"type,omitempty"`
+	ID   string                 `json:"id,omitempty"`
+	Body map[string]interface{} `json:"body,omitempty"`
+
+	Routing         string `json:"routing,omitempty"`
+	Pipeline        string `json:"pipeline,omitempty"`
+	RequireAlias    bool   `json:"require_alias,omitempty"`
+	IfSeqNo         *int   `json:"if_seq_no,omitempty"`
+	IfPrimaryTerm   *int   `json:"if_primary_term,omitempty"`
+	RetryOnConflict int    `json:"retry_on_conflict,omitempty"`
+
+	Script         map[string]interface{} `json:"script,omitempty"`
+	Upsert         map[string]interface{} `json:"upsert,omitempty"`
+	DocAsUpsert    bool                   `json:"doc_as_upsert,omitempty"`
+	ScriptedUpsert bool                   `json:"scripted_upsert,omitempty"`
+}
+
+// bulkActionMeta is the metadata line of a bulk action
+type bulkActionMeta struct {
+	Index           string `json:"_index"`
+	ID              string `json:"_id,omitempty"`
+	Routing         string `json:"routing,omitempty"`
+	Pipeline        string `json:"pipeline,omitempty"`
+	RequireAlias    bool   `json:"require_alias,omitempty"`
+	IfSeqNo         *int   `json:"if_seq_no,omitempty"`
+	IfPrimaryTerm   *int   `json:"if_primary_term,omitempty"`
+	RetryOnConflict int    `json:"retry_on_conflict,omitempty"`
+}
+
+// BulkIngestRequest represents a streaming ingestion of documents into a single index.
+// Zero values of Workers, FlushBytes and FlushInterval use the BulkIndexer defaults.
+type BulkIngestRequest struct {
+	// Reader provides the payload; it is read exactly once
+	Reader io.Reader `json:"-"`
+	Index  string    `json:"index"`
+	// Format is ndjson (default), json (a top-level array) or csv (with a header row)
+	Format string `json:"format,omitempty"`
+	// IDField names the document field used as document ID; empty lets Elasticsearch generate IDs
+	IDField string `json:"id_field,omitempty"`
+	// OpType is index (default) or create
+	OpType   string `json:"op_type,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
+	// Refresh is true, false or wait_for; empty uses the configured bulk default
+	Refresh string `json:"refresh,omitempty"`
+
+	Workers       int           `json:"workers,omitempty"`
+	FlushBytes    int           `json:"flush_bytes,omitempty"`
+	FlushInterval time.Duration `json:"flush_interval,omitempty"`
+}
+
+// BulkIngestResponse summarizes a streaming ingestion with counters,
+// throughput and the first failures
+type BulkIngestResponse struct {
+	Added          uint64  `json:"added"`
+	Indexed        uint64  `json:"indexed"`
+	Created        uint64  `json:"created"`
+	Failed         uint64  `json:"failed"`
+	Requests       uint64  `json:"requests"`
+	BytesRead      int64   `json:"bytes_read"`
+	DurationMillis int64   `json:"duration_millis"`
+	DocsPerSecond  float64 `json:"docs_per_second"`
+	MBPerSecond    float64 `json:"mb_per_second"`
+
+	Failures []BulkIngestFailure `json:"failures,omitempty"`
+	// FailuresTruncated is set when more failures occurred than are listed
+	FailuresTruncated bool `json:"failures_truncated,omitempty"`
+}
+
+// BulkIngestFailure describes a record that could not be parsed or indexed.
+// Position is the 1-based line (NDJSON) or record (JSON array, CSV) number,
+// or 0 for failures of a whole bulk request.
+type BulkIngestFailure struct {
+	Position int    `json:"position,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Reason   string `json:"reason"`
 }
 
 // BulkResponse represents the response from bulk operations
@@ -157,6 +690,42 @@ type BulkItemResponse struct {
 	} `json:"error,omitempty"`
 }
 
+// ResponseError represents an error response returned by Elasticsearch.
+// It keeps the error type and reason so callers can react to specific failures
+// (e.g. mapping conflicts) instead of parsing raw response text.
+type ResponseError struct {
+	StatusCode int          `json:"status"`
+	Type       string       `json:"type,omitempty"`
+	Reason     string       `json:"reason"`
+	RootCause  []ErrorCause `json:"root_cause,omitempty"`
+	CausedBy   *ErrorCause  `json:"caused_by,omitempty"`
+}
+
+// ErrorCause represents a single cause in an Elasticsearch error chain
+type ErrorCause struct {
+	Type     string      `json:"type"`
+	Reason   string      `json:"reason"`
+	Index    string      `json:"index,omitempty"`
+	CausedBy *ErrorCause `json:"caused_by,omitempty"`
+}
+
+// Error implements the error interface
+func (e *ResponseError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("elasticsearch error: [%d] %s", e.StatusCode, e.Reason)
+	}
+	return fmt.Sprintf("elasticsearch error: [%d] %s: %s", e.StatusCode, e.Type, e.Reason)
+}
+
+// ErrVersionConflict matches (via errors.Is) a *ResponseError caused by a
+// failed optimistic concurrency check or any other version conflict
+var ErrVersionConflict = errors.New("version conflict")
+
+// Is reports whether the error matches target, allowing errors.Is(err, ErrVersionConflict)
+func (e *ResponseError) Is(target error) bool {
+	return target == ErrVersionConflict && e.StatusCode == http.StatusConflict
+}
+
 // bodyReader implements io.Reader interface for request bodies
 type bodyReader struct {
 	data []byte
@@ -0,0 +1,115 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateRequestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		req  UpdateRequest
+		want string
+	}{
+		{
+			name: "partial document",
+			req:  UpdateRequest{Doc: map[string]interface{}{"title": "a"}, DocAsUpsert: true},
+			want: `{"doc":{"title":"a"},"doc_as_upsert":true}`,
+		},
+		{
+			name: "scripted upsert",
+			req: UpdateRequest{
+				Script:         map[string]interface{}{"source": "ctx._source.n += 1"},
+				Upsert:         map[string]interface{}{"n": 0},
+				ScriptedUpsert: true,
+			},
+			want: `{"script":{"source":"ctx._source.n += 1"},"upsert":{"n":0},"scripted_upsert":true}`,
+		},
+		{
+			name: "retry_on_conflict is not part of the body",
+			req:  UpdateRequest{Doc: map[string]interface{}{"title": "a"}, RetryOnConflict: 3},
+			want: `{"doc":{"title":"a"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(&tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseErrorIsVersionConflict(t *testing.T) {
+	conflict := &ResponseError{StatusCode: http.StatusConflict, Type: "version_conflict_engine_exception"}
+	if !errors.Is(conflict, ErrVersionConflict) {
+		t.Error("409 response does not match ErrVersionConflict")
+	}
+	if wrapped := fmt.Errorf("update failed: %w", conflict); !errors.Is(wrapped, ErrVersionConflict) {
+		t.Error("wrapped 409 response does not match ErrVersionConflict")
+	}
+
+	badRequest := &ResponseError{StatusCode: http.StatusBadRequest, Type: "illegal_argument_exception"}
+	if errors.Is(badRequest, ErrVersionConflict) {
+		t.Error("400 response matches ErrVersionConflict")
+	}
+}
+
+func TestTaskInfoByQueryStatus(t *testing.T) {
+	decode := func(t *testing.T, body string) *TaskResponse {
+		t.Helper()
+		var task TaskResponse
+		if err := json.Unmarshal([]byte(body), &task); err != nil {
+			t.Fatal(err)
+		}
+		return &task
+	}
+
+	t.Run("reindex task", func(t *testing.T) {
+		task := decode(t, `{
+			"completed": false,
+			"task": {
+				"node": "n1", "id": 42, "type": "transport",
+				"action": "indices:data/write/reindex",
+				"status": {"total": 1000, "created": 250, "updated": 10, "batches": 3, "version_conflicts": 2, "requests_per_second": -1}
+			}
+		}`)
+
+		status := task.Task.ByQueryStatus()
+		if status == nil {
+			t.Fatal("status = nil, want the reindex progress")
+		}
+		if status.Total != 1000 || status.Created != 250 || status.Updated != 10 || status.VersionConflicts != 2 {
+			t.Errorf("status = %+v", *status)
+		}
+	})
+
+	t.Run("other task keeps its raw status", func(t *testing.T) {
+		task := decode(t, `{
+			"completed": false,
+			"task": {"node": "n1", "id": 7, "action": "indices:data/write/bulk", "status": {"phase": "indexing"}}
+		}`)
+
+		if status := task.Task.ByQueryStatus(); status != nil {
+			t.Errorf("status = %+v, want nil for a bulk task", *status)
+		}
+		if string(task.Task.Status) != `{"phase": "indexing"}` {
+			t.Errorf("raw status = %s", task.Task.Status)
+		}
+	})
+
+	t.Run("by query task without status", func(t *testing.T) {
+		task := decode(t, `{"completed": true, "task": {"node": "n1", "id": 9, "action": "indices:data/write/delete/byquery"}}`)
+		if status := task.Task.ByQueryStatus(); status != nil {
+			t.Errorf("status = %+v, want nil", *status)
+		}
+	})
+}
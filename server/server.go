@@ -35,7 +35,7 @@ func NewElasticsearchMCPServer(cfg *config.Config) (*ElasticsearchMCPServer, err
 	log.Printf("Connected to Elasticsearch")
 
 	// Create the tools collection with the Elasticsearch client
-	esTools := tools.NewElasticsearchTools(esClient)
+	esTools := tools.NewElasticsearchTools(esClient, cfg.Server.IngestDir)
 
 	// Create the MCP server
 	impl := &mcp.Implementation{
@@ -74,6 +74,21 @@ func registerTools(mcpServer *mcp.Server, esTools *tools.ElasticsearchTools) err
 		handler := func(ctx context.Context, ss *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]any]) (*mcp.CallToolResult, error) {
 			log.Printf("Tool call: %s", toolName)
 
+			// Forward progress of long-running tools if the client asked for it
+			if token := params.GetProgressToken(); token != nil {
+				ctx = tools.WithProgressReporter(ctx, func(progress, total float64, message string) {
+					err := ss.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+						ProgressToken: token,
+						Progress:      progress,
+						Total:         total,
+						Message:       message,
+					})
+					if err != nil {
+						log.Printf("Failed to send progress for tool %s: %v", toolName, err)
+					}
+				})
+			}
+
 			result := esTools.HandleTool(ctx, toolName, params.Arguments)
 
 			if result.IsError {
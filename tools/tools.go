@@ -4,10 +4,22 @@
 package tools
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/AeaZer/mcp-elasticsearch/config"
 	"github.com/AeaZer/mcp-elasticsearch/elasticsearch"
 	"github.com/modelcontextprotocol/go-sdk/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -15,13 +27,44 @@ import (
 
 // ElasticsearchTools represents a collection of Elasticsearch-related MCP tools
 type ElasticsearchTools struct {
-	client elasticsearch.Client // Elasticsearch client for performing operations
+	client    elasticsearch.Client // Elasticsearch client for performing operations
+	ingestDir string               // Directory es_bulk_ingest may read files from (empty disables files)
 }
 
-// NewElasticsearchTools creates a new instance of ElasticsearchTools with the provided client
-func NewElasticsearchTools(client elasticsearch.Client) *ElasticsearchTools {
+// ProgressReporter reports the progress of a long-running tool call,
+// e.g. as MCP progress notifications
+type ProgressReporter func(progress, total float64, message string)
+
+// progressReporterKey is the context key of the ProgressReporter
+type progressReporterKey struct{}
+
+// WithProgressReporter returns a context through which tool handlers report progress
+func WithProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey{}, reporter)
+}
+
+// progressReporterFrom returns the ProgressReporter of ctx, or one that discards progress
+func progressReporterFrom(ctx context.Context) ProgressReporter {
+	if reporter, ok := ctx.Value(progressReporterKey{}).(ProgressReporter); ok && reporter != nil {
+		return reporter
+	}
+	return func(progress, total float64, message string) {}
+}
+
+// asyncSearchPollInterval is how long es_async_search_submit waits per request
+// before checking the progress of a running search again
+const asyncSearchPollInterval = 2 * time.Second
+
+// eqlWaitForCompletionTimeout is how long es_eql_search waits for results by
+// default before the search continues asynchronously
+const eqlWaitForCompletionTimeout = 10 * time.Second
+
+// NewElasticsearchTools creates a new instance of ElasticsearchTools with the provided client.
+// ingestDir restricts the files es_bulk_ingest may read; an empty value allows inline data only.
+func NewElasticsearchTools(client elasticsearch.Client, ingestDir string) *ElasticsearchTools {
 	return &ElasticsearchTools{
-		client: client,
+		client:    client,
+		ingestDir: ingestDir,
 	}
 }
 
@@ -103,207 +146,1570 @@ func (et *ElasticsearchTools) GetTools() []mcp.Tool {
 			},
 		},
 		{
-			Name:        "es_document_index",
-			Description: "Index a document with optional ID",
+			Name:        "es_index_get_mapping",
+			Description: "Get the field mappings of an index",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"index": {
 						Type:        "string",
-						Description: "Index name",
+						Description: "Index name or pattern",
 					},
-					"id": {
+				},
+				Required: []string{"index"},
+			},
+		},
+		{
+			Name:        "es_index_put_mapping",
+			Description: "Add new fields or update mapping parameters of an existing index",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
 						Type:        "string",
-						Description: "Document ID (optional)",
+						Description: "Index name or pattern",
 					},
-					"body": {
+					"mappings": {
 						Type:        "object",
-						Description: "Document body",
+						Description: "Mapping definition to merge, e.g. {\"properties\": {\"field\": {\"type\": \"keyword\"}}}",
 					},
 				},
-				Required: []string{"index", "body"},
+				Required: []string{"index", "mappings"},
 			},
 		},
 		{
-			Name:        "es_document_get",
-			Description: "Retrieve a document by ID",
+			Name:        "es_index_get_settings",
+			Description: "Get the settings of an index",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"index": {
 						Type:        "string",
-						Description: "Index name",
+						Description: "Index name or pattern",
 					},
-					"id": {
+					"include_defaults": {
+						Type:        "boolean",
+						Description: "Also return default values of settings that are not set explicitly (default: false)",
+					},
+				},
+				Required: []string{"index"},
+			},
+		},
+		{
+			Name:        "es_index_put_settings",
+			Description: "Update dynamic settings of an existing index, e.g. number_of_replicas or refresh_interval",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
 						Type:        "string",
-						Description: "Document ID",
+						Description: "Index name or pattern",
+					},
+					"settings": {
+						Type:        "object",
+						Description: "Settings to apply, e.g. {\"index\": {\"number_of_replicas\": 1}}",
 					},
 				},
-				Required: []string{"index", "id"},
+				Required: []string{"index", "settings"},
 			},
 		},
 		{
-			Name:        "es_document_update",
-			Description: "Update an existing document",
+			Name:        "es_field_caps",
+			Description: "Get field names, types and whether they are searchable or aggregatable across indices",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"index": {
 						Type:        "string",
-						Description: "Index name",
+						Description: "Index name or pattern (optional, inspects all if not provided)",
 					},
-					"id": {
+					"fields": {
+						Type:        "array",
+						Description: "Field names or wildcard patterns (default: all fields)",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "es_data_stream_list",
+			Description: "List data streams with their generation, status, template and backing index count",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+		},
+		{
+			Name:        "es_data_stream_get",
+			Description: "Get data streams with their backing indices, generation and lifecycle policy",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
 						Type:        "string",
-						Description: "Document ID",
+						Description: "Data stream name or wildcard pattern",
 					},
-					"body": {
-						Type:        "object",
-						Description: "Update body with doc or script",
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "es_data_stream_create",
+			Description: "Create a data stream (requires a matching index template with data_stream enabled)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Data stream name",
 					},
 				},
-				Required: []string{"index", "id", "body"},
+				Required: []string{"name"},
 			},
 		},
 		{
-			Name:        "es_document_delete",
-			Description: "Delete a document by ID",
+			Name:        "es_data_stream_delete",
+			Description: "Delete a data stream and all of its backing indices",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
-					"index": {
+					"name": {
 						Type:        "string",
-						Description: "Index name",
+						Description: "Data stream name",
 					},
-					"id": {
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "es_rollover",
+			Description: "Roll over a data stream or index alias to a new write index",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": {
 						Type:        "string",
-						Description: "Document ID",
+						Description: "Data stream or alias name",
+					},
+					"conditions": {
+						Type:        "object",
+						Description: "Rollover conditions, e.g. {\"max_age\": \"7d\", \"max_primary_shard_size\": \"50gb\"} (optional, rolls over unconditionally if not provided)",
+					},
+					"dry_run": {
+						Type:        "boolean",
+						Description: "Only evaluate the conditions without rolling over (default: false)",
 					},
 				},
-				Required: []string{"index", "id"},
+				Required: []string{"target"},
 			},
 		},
 		{
-			Name:        "es_search",
-			Description: "Execute search queries with filters and sorting",
+			Name:        "es_ilm_policy_list",
+			Description: "List ILM policies with their phases and the indices using them",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+		},
+		{
+			Name:        "es_ilm_policy_get",
+			Description: "Get an ILM policy definition",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
-					"index": {
+					"name": {
 						Type:        "string",
-						Description: "Index name (optional, searches all if not provided)",
+						Description: "Policy name",
 					},
-					"query": {
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "es_ilm_policy_put",
+			Description: "Create or replace an ILM policy",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Policy name",
+					},
+					"policy": {
 						Type:        "object",
-						Description: "Search query",
+						Description: "Policy definition, e.g. {\"phases\": {\"hot\": {\"actions\": {\"rollover\": {\"max_age\": \"7d\"}}}}}",
 					},
-					"size": {
-						Type:        "integer",
-						Description: "Number of results to return (default: 10)",
+				},
+				Required: []string{"name", "policy"},
+			},
+		},
+		{
+			Name:        "es_ilm_policy_delete",
+			Description: "Delete an ILM policy that is not in use",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Policy name",
 					},
-					"from": {
-						Type:        "integer",
-						Description: "Offset for pagination (default: 0)",
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "es_ilm_explain",
+			Description: "Explain the current ILM phase, action and step of indices, including step errors",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name, data stream or pattern",
 					},
-					"sort": {
-						Type:        "array",
-						Description: "Sort specification (array of sort objects)",
-						Items: &jsonschema.Schema{
-							Type: "object",
-						},
+					"only_errors": {
+						Type:        "boolean",
+						Description: "Only report indices whose lifecycle step failed (default: false)",
 					},
-					"_source": {
-						Description: "Source filtering: boolean (true/false), array of field names, or object with includes/excludes",
-						OneOf: []*jsonschema.Schema{
-							{Type: "boolean"},
-							{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
-							{Type: "object"},
-						},
+				},
+				Required: []string{"index"},
+			},
+		},
+		{
+			Name:        "es_alias_list",
+			Description: "List all aliases with their indices, routing and whether they have a filter (use es_alias_get for filter definitions)",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+		},
+		{
+			Name:        "es_alias_get",
+			Description: "Get alias definitions by alias name and/or index",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"alias": {
+						Type:        "string",
+						Description: "Alias name or pattern (optional)",
+					},
+					"index": {
+						Type:        "string",
+						Description: "Index name or pattern (optional)",
 					},
 				},
 			},
 		},
 		{
-			Name:        "es_bulk",
-			Description: "Execute multiple operations in a single request",
+			Name:        "es_alias_update",
+			Description: "Atomically add and remove aliases in a single request",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
-					"operations": {
+					"actions": {
 						Type:        "array",
-						Description: "Array of bulk operations",
+						Description: "Alias actions applied in order, all or nothing",
 						Items: &jsonschema.Schema{
 							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"action": {
+									Type:        "string",
+									Description: "Action type",
+									Enum:        []any{"add", "remove", "remove_index"},
+								},
+								"index": {
+									Type:        "string",
+									Description: "Index name or pattern",
+								},
+								"alias": {
+									Type:        "string",
+									Description: "Alias name (required for add and remove)",
+								},
+								"filter": {
+									Type:        "object",
+									Description: "Query used to limit the documents visible through the alias (add only)",
+								},
+								"routing": {
+									Type:        "string",
+									Description: "Routing value for both indexing and search (add only)",
+								},
+								"index_routing": {
+									Type:        "string",
+									Description: "Routing value for indexing (add only)",
+								},
+								"search_routing": {
+									Type:        "string",
+									Description: "Routing value for search (add only)",
+								},
+								"is_write_index": {
+									Type:        "boolean",
+									Description: "Whether the index is the write index of the alias (add only)",
+								},
+							},
+							Required: []string{"action", "index"},
 						},
 					},
 				},
-				Required: []string{"operations"},
+				Required: []string{"actions"},
 			},
 		},
-	}
-}
-
-// Helper functions to create standardized MCP results
-
-// createErrorResult creates a standardized error result
-func createErrorResult(message string) mcp.CallToolResult {
-	return mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("Error: %s", message),
+		{
+			Name:        "es_index_template_list",
+			Description: "List composable index templates with their index patterns, priority and component templates",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
 			},
 		},
-		IsError: true,
-	}
-}
-
-// createSuccessResult creates a standardized success result with structured data
-func createSuccessResult(text string, data interface{}) mcp.CallToolResult {
-	content := []mcp.Content{
-		&mcp.TextContent{
-			Text: text,
+		{
+			Name:        "es_index_template_get",
+			Description: "Get the full definition of composable index templates",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Template name or wildcard pattern",
+					},
+				},
+				Required: []string{"name"},
+			},
 		},
-	}
-
-	result := mcp.CallToolResult{
-		Content: content,
-		IsError: false,
-	}
-
-	if data != nil {
-		switch v := data.(type) {
-		case []interface{}:
-			result.StructuredContent = map[string]interface{}{
-				"data":  v,
-				"count": len(v),
-			}
-		case []string:
-			result.StructuredContent = map[string]interface{}{
-				"data":  v,
-				"count": len(v),
-			}
-		default:
-			result.StructuredContent = data
-		}
-	}
-
-	return result
-}
-
-// createSimpleSuccessResult creates a simple success result with only text
-func createSimpleSuccessResult(text string) mcp.CallToolResult {
-	return mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: text,
+		{
+			Name:        "es_index_template_put",
+			Description: "Create or replace a composable index template",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Template name",
+					},
+					"body": {
+						Type:        "object",
+						Description: "Template definition with index_patterns, template (settings, mappings, aliases), composed_of, priority, data_stream, _meta",
+					},
+				},
+				Required: []string{"name", "body"},
 			},
 		},
-		IsError: false,
-	}
-}
-
-// HandleTool handles MCP tool calls and routes them to appropriate handlers
-func (et *ElasticsearchTools) HandleTool(ctx context.Context, toolName string, arguments map[string]interface{}) mcp.CallToolResult {
-	switch toolName {
+		{
+			Name:        "es_index_template_delete",
+			Description: "Delete a composable index template",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Template name",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "es_index_template_simulate",
+			Description: "Preview the final settings, mappings and aliases an index name would receive from matching templates",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name to simulate (does not need to exist)",
+					},
+				},
+				Required: []string{"index"},
+			},
+		},
+		{
+			Name:        "es_component_template_list",
+			Description: "List component templates",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+		},
+		{
+			Name:        "es_component_template_get",
+			Description: "Get the full definition of component templates",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Template name or wildcard pattern",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "es_component_template_put",
+			Description: "Create or replace a component template",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Template name",
+					},
+					"body": {
+						Type:        "object",
+						Description: "Template definition with template (settings, mappings, aliases), version, _meta",
+					},
+				},
+				Required: []string{"name", "body"},
+			},
+		},
+		{
+			Name:        "es_component_template_delete",
+			Description: "Delete a component template that is no longer used by any index template",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Template name",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		{
+			Name:        "es_document_index",
+			Description: "Index a document with optional ID",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name",
+					},
+					"id": {
+						Type:        "string",
+						Description: "Document ID (optional)",
+					},
+					"body": {
+						Type:        "object",
+						Description: "Document body",
+					},
+					"routing": {
+						Type:        "string",
+						Description: "Custom routing value, e.g. a tenant ID",
+					},
+					"op_type": {
+						Type:        "string",
+						Description: "Use 'create' to fail instead of overwriting an existing document (default: index)",
+						Enum:        []any{"index", "create"},
+					},
+					"pipeline": {
+						Type:        "string",
+						Description: "Ingest pipeline to run on the document",
+					},
+					"refresh": {
+						Type:        "string",
+						Description: "Refresh policy: true, false or wait_for (default: server configuration)",
+						Enum:        []any{"true", "false", "wait_for"},
+					},
+					"if_seq_no": {
+						Type:        "integer",
+						Description: "Only write if the document still has this sequence number (use with if_primary_term, both from es_document_get)",
+					},
+					"if_primary_term": {
+						Type:        "integer",
+						Description: "Only write if the document still has this primary term (use with if_seq_no)",
+					},
+					"version": {
+						Type:        "integer",
+						Description: "Explicit version for external versioning (use with version_type)",
+					},
+					"version_type": {
+						Type:        "string",
+						Description: "Version type for 'version'",
+						Enum:        []any{"internal", "external", "external_gte"},
+					},
+				},
+				Required: []string{"index", "body"},
+			},
+		},
+		{
+			Name:        "es_document_get",
+			Description: "Retrieve a document by ID",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name",
+					},
+					"id": {
+						Type:        "string",
+						Description: "Document ID",
+					},
+					"routing": {
+						Type:        "string",
+						Description: "Custom routing value used when the document was indexed",
+					},
+				},
+				Required: []string{"index", "id"},
+			},
+		},
+		{
+			Name:        "es_document_mget",
+			Description: "Retrieve multiple documents by ID, possibly across indices, in a single request",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"docs": {
+						Type:        "array",
+						Description: "Documents to retrieve",
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"index": {
+									Type:        "string",
+									Description: "Index name",
+								},
+								"id": {
+									Type:        "string",
+									Description: "Document ID",
+								},
+								"routing": {
+									Type:        "string",
+									Description: "Custom routing value used when the document was indexed",
+								},
+								"_source": {
+									Description: "Source filtering: boolean, array of field names, or object with includes/excludes",
+									OneOf: []*jsonschema.Schema{
+										{Type: "boolean"},
+										{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+										{Type: "object"},
+									},
+								},
+							},
+							Required: []string{"index", "id"},
+						},
+					},
+				},
+				Required: []string{"docs"},
+			},
+		},
+		{
+			Name:        "es_document_update",
+			Description: "Update a document with a partial doc or a script, optionally creating it with upsert or doc_as_upsert if it does not exist",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name",
+					},
+					"id": {
+						Type:        "string",
+						Description: "Document ID",
+					},
+					"doc": {
+						Type:        "object",
+						Description: "Partial document to merge into the existing document",
+					},
+					"script": {
+						Description: "Script to run on the document: source string or {source, lang, params}",
+						OneOf: []*jsonschema.Schema{
+							{Type: "string"},
+							{Type: "object"},
+						},
+					},
+					"upsert": {
+						Type:        "object",
+						Description: "Document to index if the document does not exist",
+					},
+					"doc_as_upsert": {
+						Type:        "boolean",
+						Description: "Use 'doc' as the upsert document if the document does not exist",
+					},
+					"scripted_upsert": {
+						Type:        "boolean",
+						Description: "Run the script even if the document does not exist, starting from 'upsert'",
+					},
+					"retry_on_conflict": {
+						Type:        "integer",
+						Description: "How many times to retry the update on a version conflict",
+					},
+					"body": {
+						Type:        "object",
+						Description: "Deprecated: full update body with doc or script, or a partial document; cannot be combined with doc, script, upsert, doc_as_upsert or scripted_upsert",
+					},
+					"routing": {
+						Type:        "string",
+						Description: "Custom routing value used when the document was indexed",
+					},
+					"refresh": {
+						Type:        "string",
+						Description: "Refresh policy: true, false or wait_for (default: server configuration)",
+						Enum:        []any{"true", "false", "wait_for"},
+					},
+					"if_seq_no": {
+						Type:        "integer",
+						Description: "Only write if the document still has this sequence number (use with if_primary_term, both from es_document_get)",
+					},
+					"if_primary_term": {
+						Type:        "integer",
+						Description: "Only write if the document still has this primary term (use with if_seq_no)",
+					},
+				},
+				Required: []string{"index", "id"},
+			},
+		},
+		{
+			Name:        "es_document_delete",
+			Description: "Delete a document by ID",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name",
+					},
+					"id": {
+						Type:        "string",
+						Description: "Document ID",
+					},
+					"routing": {
+						Type:        "string",
+						Description: "Custom routing value used when the document was indexed",
+					},
+					"refresh": {
+						Type:        "string",
+						Description: "Refresh policy: true, false or wait_for (default: server configuration)",
+						Enum:        []any{"true", "false", "wait_for"},
+					},
+					"if_seq_no": {
+						Type:        "integer",
+						Description: "Only write if the document still has this sequence number (use with if_primary_term, both from es_document_get)",
+					},
+					"if_primary_term": {
+						Type:        "integer",
+						Description: "Only write if the document still has this primary term (use with if_seq_no)",
+					},
+					"version": {
+						Type:        "integer",
+						Description: "Explicit version for external versioning (use with version_type)",
+					},
+					"version_type": {
+						Type:        "string",
+						Description: "Version type for 'version'",
+						Enum:        []any{"internal", "external", "external_gte"},
+					},
+				},
+				Required: []string{"index", "id"},
+			},
+		},
+		{
+			Name:        "es_search",
+			Description: "Execute search queries with filters and sorting",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name (optional, searches all if not provided)",
+					},
+					"query": {
+						Type:        "object",
+						Description: "Search query",
+					},
+					"size": {
+						Type:        "integer",
+						Description: "Number of results to return (default: 10)",
+					},
+					"from": {
+						Type:        "integer",
+						Description: "Offset for pagination (default: 0)",
+					},
+					"sort": {
+						Type:        "array",
+						Description: "Sort specification (array of sort objects)",
+						Items: &jsonschema.Schema{
+							Type: "object",
+						},
+					},
+					"_source": {
+						Description: "Source filtering: boolean (true/false), array of field names, or object with includes/excludes",
+						OneOf: []*jsonschema.Schema{
+							{Type: "boolean"},
+							{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+							{Type: "object"},
+						},
+					},
+					"aggs": {
+						Type:        "object",
+						Description: "Aggregations to compute, keyed by aggregation name (alias: aggregations). Use size 0 for aggregation-only requests",
+					},
+					"aggregations": {
+						Type:        "object",
+						Description: "Alias of 'aggs'",
+					},
+					"highlight": {
+						Type:        "object",
+						Description: "Highlight specification, e.g. {\"fields\": {\"message\": {}}}; snippets are returned per hit",
+					},
+					"fields": {
+						Type:        "array",
+						Description: "Fields to retrieve per hit via the fields API (field names or {field, format} objects)",
+					},
+					"explain": {
+						Type:        "boolean",
+						Description: "Return a scoring explanation for each hit",
+					},
+					"include_named_queries_score": {
+						Type:        "boolean",
+						Description: "Report matched_queries with scores; name query clauses with '_name' to see which ones matched",
+					},
+					"knn": {
+						Description: "kNN vector search clause, or array of clauses: {field, query_vector, k, num_candidates, filter, similarity}. Combine with 'query' for hybrid search",
+						OneOf: []*jsonschema.Schema{
+							{Type: "object"},
+							{Type: "array", Items: &jsonschema.Schema{Type: "object"}},
+						},
+					},
+					"rank": {
+						Type:        "object",
+						Description: "Fuse 'query' and 'knn' results by rank instead of summing scores, e.g. {\"rrf\": {}} (Elasticsearch 8.8+)",
+					},
+					"search_after": {
+						Type:        "array",
+						Description: "Sort values of the last hit of the previous page (requires 'sort')",
+					},
+					"pit_keep_alive": {
+						Type:        "string",
+						Description: "Open a point in time on 'index' and return a 'cursor' for stable deep pagination, e.g. '1m'",
+					},
+					"cursor": {
+						Type:        "string",
+						Description: "Cursor returned by a previous es_search call to fetch the next page. Re-send the same query, sort, knn and rank; index and from are ignored",
+					},
+				},
+			},
+		},
+		{
+			Name:        "es_count",
+			Description: "Count documents matching a query without fetching hits",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name (optional, counts all if not provided)",
+					},
+					"query": {
+						Type:        "object",
+						Description: "Search query (optional, counts all documents if not provided)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "es_pit_close",
+			Description: "Release the point in time behind an es_search cursor before it expires",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"cursor": {
+						Type:        "string",
+						Description: "Cursor returned by es_search",
+					},
+				},
+				Required: []string{"cursor"},
+			},
+		},
+		{
+			Name:        "es_async_search_submit",
+			Description: "Start a long-running search, e.g. heavy aggregations, that continues in the background and returns partial results with an id to poll",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name (optional, searches all if not provided)",
+					},
+					"query": {
+						Type:        "object",
+						Description: "Search query",
+					},
+					"size": {
+						Type:        "integer",
+						Description: "Number of results to return (default: 10)",
+					},
+					"from": {
+						Type:        "integer",
+						Description: "Offset for pagination (default: 0)",
+					},
+					"sort": {
+						Type:        "array",
+						Description: "Sort specification (array of sort objects)",
+						Items: &jsonschema.Schema{
+							Type: "object",
+						},
+					},
+					"_source": {
+						Description: "Source filtering: boolean (true/false), array of field names, or object with includes/excludes",
+						OneOf: []*jsonschema.Schema{
+							{Type: "boolean"},
+							{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+							{Type: "object"},
+						},
+					},
+					"aggs": {
+						Type:        "object",
+						Description: "Aggregations to compute, keyed by aggregation name. Use size 0 for aggregation-only requests",
+					},
+					"aggregations": {
+						Type:        "object",
+						Description: "Alias of 'aggs'",
+					},
+					"highlight": {
+						Type:        "object",
+						Description: "Highlight specification, e.g. {\"fields\": {\"message\": {}}}; snippets are returned per hit",
+					},
+					"fields": {
+						Type:        "array",
+						Description: "Fields to retrieve per hit via the fields API (field names or {field, format} objects)",
+					},
+					"explain": {
+						Type:        "boolean",
+						Description: "Return a scoring explanation for each hit",
+					},
+					"include_named_queries_score": {
+						Type:        "boolean",
+						Description: "Report matched_queries with scores; name query clauses with '_name' to see which ones matched",
+					},
+					"knn": {
+						Description: "kNN vector search clause, or array of clauses: {field, query_vector, k, num_candidates, filter, similarity}. Combine with 'query' for hybrid search",
+						OneOf: []*jsonschema.Schema{
+							{Type: "object"},
+							{Type: "array", Items: &jsonschema.Schema{Type: "object"}},
+						},
+					},
+					"rank": {
+						Type:        "object",
+						Description: "Fuse 'query' and 'knn' results by rank instead of summing scores, e.g. {\"rrf\": {}} (Elasticsearch 8.8+)",
+					},
+					"wait_for_completion_timeout": {
+						Type:        "string",
+						Description: "How long to wait for the search to complete before returning partial results, e.g. 20s (default: 1s)",
+					},
+					"keep_alive": {
+						Type:        "string",
+						Description: "How long the search and its results are kept, e.g. 1h (default: 5d)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "es_async_search_get",
+			Description: "Get the current, possibly partial, results of an async search",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "Async search ID returned by es_async_search_submit",
+					},
+					"wait_for_completion_timeout": {
+						Type:        "string",
+						Description: "How long to wait for the search to complete, e.g. 20s (default: return immediately)",
+					},
+					"keep_alive": {
+						Type:        "string",
+						Description: "Extend how long the search and its results are kept, e.g. 1h",
+					},
+				},
+				Required: []string{"id"},
+			},
+		},
+		{
+			Name:        "es_async_search_status",
+			Description: "Check whether an async search is still running and how many shards have completed, without fetching results",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "Async search ID returned by es_async_search_submit",
+					},
+				},
+				Required: []string{"id"},
+			},
+		},
+		{
+			Name:        "es_async_search_delete",
+			Description: "Cancel an async search if it is still running and delete its results",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "Async search ID returned by es_async_search_submit",
+					},
+				},
+				Required: []string{"id"},
+			},
+		},
+		{
+			Name:        "es_eql_search",
+			Description: "Run an EQL query for events or sequences of events, continuing asynchronously if it takes long",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name or pattern, e.g. logs-endpoint-*",
+					},
+					"query": {
+						Type:        "string",
+						Description: "EQL query, e.g. sequence by process.pid [process where process.name == \"cmd.exe\"] [network where true]",
+					},
+					"timestamp_field": {
+						Type:        "string",
+						Description: "Field containing the event timestamp (default: @timestamp)",
+					},
+					"event_category_field": {
+						Type:        "string",
+						Description: "Field containing the event classification (default: event.category)",
+					},
+					"tiebreaker_field": {
+						Type:        "string",
+						Description: "Field used to sort events with the same timestamp",
+					},
+					"filter": {
+						Type:        "object",
+						Description: "Query DSL filter applied before the EQL query runs",
+					},
+					"size": {
+						Type:        "integer",
+						Description: "Maximum number of events or sequences to return (default: 10)",
+					},
+					"fetch_size": {
+						Type:        "integer",
+						Description: "Maximum number of events to search at a time for sequence queries (default: 1000)",
+					},
+					"wait_for_completion_timeout": {
+						Type:        "string",
+						Description: "How long to wait for results before continuing asynchronously and returning an id for es_eql_get, e.g. 30s (default: 10s)",
+					},
+					"keep_alive": {
+						Type:        "string",
+						Description: "How long asynchronous results are kept, e.g. 1h (default: 5d)",
+					},
+				},
+				Required: []string{"index", "query"},
+			},
+		},
+		{
+			Name:        "es_eql_get",
+			Description: "Get the results of an asynchronous EQL search",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "Search ID returned by es_eql_search",
+					},
+					"wait_for_completion_timeout": {
+						Type:        "string",
+						Description: "How long to wait for the search to complete, e.g. 10s (default: wait until complete)",
+					},
+				},
+				Required: []string{"id"},
+			},
+		},
+		{
+			Name:        "es_sql",
+			Description: "Run a SQL query and return columns and rows, with a cursor for further pages",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "SQL query, e.g. SELECT author, COUNT(*) FROM library GROUP BY author (use either 'query' or 'cursor')",
+					},
+					"cursor": {
+						Type:        "string",
+						Description: "Cursor returned by a previous call to fetch the next page (use either 'query' or 'cursor')",
+					},
+					"fetch_size": {
+						Type:        "integer",
+						Description: "Maximum number of rows per page (default: 1000)",
+					},
+					"filter": {
+						Type:        "object",
+						Description: "Query DSL filter applied before the SQL query runs",
+					},
+					"time_zone": {
+						Type:        "string",
+						Description: "Time zone for date and time functions, e.g. Europe/Berlin (default: Z)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "es_sql_translate",
+			Description: "Translate a SQL query into the equivalent Query DSL search request",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "SQL query to translate",
+					},
+					"fetch_size": {
+						Type:        "integer",
+						Description: "Maximum number of rows per page",
+					},
+					"filter": {
+						Type:        "object",
+						Description: "Query DSL filter applied before the SQL query runs",
+					},
+					"time_zone": {
+						Type:        "string",
+						Description: "Time zone for date and time functions",
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+		{
+			Name:        "es_esql",
+			Description: "Run an ES|QL query (Elasticsearch 8.11+) and return typed columns and rows",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "ES|QL query, e.g. FROM logs | WHERE status >= ? | STATS count = COUNT(*) BY host",
+					},
+					"params": {
+						Type:        "array",
+						Description: "Values bound to ? placeholders in order, or {\"name\": value} objects bound to ?name placeholders",
+					},
+					"filter": {
+						Type:        "object",
+						Description: "Query DSL filter applied before the ES|QL query runs",
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+		{
+			Name:        "es_reindex",
+			Description: "Copy documents from source indices into a destination index as a background task; poll progress with es_task_get",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"source_index": {
+						Description: "Source index name, pattern, or array of names",
+						OneOf: []*jsonschema.Schema{
+							{Type: "string"},
+							{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+						},
+					},
+					"query": {
+						Type:        "object",
+						Description: "Query selecting the source documents (optional, copies all if not provided)",
+					},
+					"dest_index": {
+						Type:        "string",
+						Description: "Destination index name",
+					},
+					"script": {
+						Description: "Painless script transforming each document: source string or {source, lang, params}",
+						OneOf: []*jsonschema.Schema{
+							{Type: "string"},
+							{Type: "object"},
+						},
+					},
+					"pipeline": {
+						Type:        "string",
+						Description: "Ingest pipeline applied in the destination index",
+					},
+					"slices": {
+						Description: "Number of parallel slices, or 'auto'",
+						OneOf: []*jsonschema.Schema{
+							{Type: "integer"},
+							{Type: "string", Enum: []any{"auto"}},
+						},
+					},
+					"max_docs": {
+						Type:        "integer",
+						Description: "Maximum number of documents to copy",
+					},
+					"conflicts": {
+						Type:        "string",
+						Description: "What to do on version conflicts: abort (default) or proceed",
+						Enum:        []any{"abort", "proceed"},
+					},
+					"refresh": {
+						Type:        "boolean",
+						Description: "Refresh the destination index once the reindex completes (default: false)",
+					},
+				},
+				Required: []string{"source_index", "dest_index"},
+			},
+		},
+		{
+			Name:        "es_update_by_query",
+			Description: "Update all documents matching a query, typically with a painless script",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name or pattern",
+					},
+					"query": {
+						Type:        "object",
+						Description: "Query selecting the documents to update (optional, updates all if not provided)",
+					},
+					"script": {
+						Description: "Painless script applied to each document: source string or {source, lang, params}",
+						OneOf: []*jsonschema.Schema{
+							{Type: "string"},
+							{Type: "object"},
+						},
+					},
+					"conflicts": {
+						Type:        "string",
+						Description: "What to do on version conflicts: abort (default) or proceed",
+						Enum:        []any{"abort", "proceed"},
+					},
+					"max_docs": {
+						Type:        "integer",
+						Description: "Maximum number of documents to process",
+					},
+					"slices": {
+						Description: "Number of parallel slices, or 'auto'",
+						OneOf: []*jsonschema.Schema{
+							{Type: "integer"},
+							{Type: "string", Enum: []any{"auto"}},
+						},
+					},
+					"requests_per_second": {
+						Type:        "integer",
+						Description: "Throttle to this many documents per second (default: -1, unthrottled)",
+					},
+					"wait_for_completion": {
+						Type:        "boolean",
+						Description: "Wait for the operation to finish instead of running it as a background task (default: false, poll the returned task with es_task_get)",
+					},
+					"refresh": {
+						Type:        "boolean",
+						Description: "Refresh the affected shards once the operation completes (default: false)",
+					},
+				},
+				Required: []string{"index"},
+			},
+		},
+		{
+			Name:        "es_delete_by_query",
+			Description: "Delete all documents matching a query",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index name or pattern",
+					},
+					"query": {
+						Type:        "object",
+						Description: "Query selecting the documents to delete",
+					},
+					"conflicts": {
+						Type:        "string",
+						Description: "What to do on version conflicts: abort (default) or proceed",
+						Enum:        []any{"abort", "proceed"},
+					},
+					"max_docs": {
+						Type:        "integer",
+						Description: "Maximum number of documents to process",
+					},
+					"slices": {
+						Description: "Number of parallel slices, or 'auto'",
+						OneOf: []*jsonschema.Schema{
+							{Type: "integer"},
+							{Type: "string", Enum: []any{"auto"}},
+						},
+					},
+					"requests_per_second": {
+						Type:        "integer",
+						Description: "Throttle to this many documents per second (default: -1, unthrottled)",
+					},
+					"wait_for_completion": {
+						Type:        "boolean",
+						Description: "Wait for the operation to finish instead of running it as a background task (default: false, poll the returned task with es_task_get)",
+					},
+					"refresh": {
+						Type:        "boolean",
+						Description: "Refresh the affected shards once the operation completes (default: false)",
+					},
+				},
+				Required: []string{"index", "query"},
+			},
+		},
+		{
+			Name:        "es_task_get",
+			Description: "Get the progress of a background task such as a reindex, and its result once completed",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"task_id": {
+						Type:        "string",
+						Description: "Task ID returned when the task was started (node_id:task_number)",
+					},
+				},
+				Required: []string{"task_id"},
+			},
+		},
+		{
+			Name:        "es_bulk",
+			Description: "Execute multiple operations in a single request",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"operations": {
+						Type:        "array",
+						Description: "Array of bulk operations",
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"operation": {
+									Type:        "string",
+									Description: "Operation type",
+									Enum:        []any{"index", "create", "update", "delete"},
+								},
+								"index": {
+									Type:        "string",
+									Description: "Target index",
+								},
+								"id": {
+									Type:        "string",
+									Description: "Document ID (required for update and delete)",
+								},
+								"body": {
+									Type:        "object",
+									Description: "Document source for index and create, or partial document for update ({doc, script, upsert, ...} update bodies are also accepted)",
+								},
+								"routing": {
+									Type:        "string",
+									Description: "Custom routing value",
+								},
+								"pipeline": {
+									Type:        "string",
+									Description: "Ingest pipeline (index and create only)",
+								},
+								"require_alias": {
+									Type:        "boolean",
+									Description: "Fail unless 'index' is an alias (index, create and update only)",
+								},
+								"if_seq_no": {
+									Type:        "integer",
+									Description: "Only apply if the document has this sequence number (requires if_primary_term)",
+								},
+								"if_primary_term": {
+									Type:        "integer",
+									Description: "Only apply if the document has this primary term (requires if_seq_no)",
+								},
+								"retry_on_conflict": {
+									Type:        "integer",
+									Description: "Number of times to retry an update on version conflicts (update only)",
+								},
+								"script": {
+									Description: "Script to run instead of 'body' (update only): source string or {source, lang, params}",
+									OneOf: []*jsonschema.Schema{
+										{Type: "string"},
+										{Type: "object"},
+									},
+								},
+								"upsert": {
+									Type:        "object",
+									Description: "Document to index if the document does not exist (update only)",
+								},
+								"doc_as_upsert": {
+									Type:        "boolean",
+									Description: "Use 'body' as the upsert document (update only)",
+								},
+								"scripted_upsert": {
+									Type:        "boolean",
+									Description: "Run the script even if the document does not exist (update only)",
+								},
+							},
+							Required: []string{"operation", "index"},
+						},
+					},
+					"routing": {
+						Type:        "string",
+						Description: "Default routing value for all operations",
+					},
+					"pipeline": {
+						Type:        "string",
+						Description: "Default ingest pipeline for index and create operations",
+					},
+					"refresh": {
+						Type:        "string",
+						Description: "Refresh policy: true, false or wait_for (default: server configuration)",
+						Enum:        []any{"true", "false", "wait_for"},
+					},
+				},
+				Required: []string{"operations"},
+			},
+		},
+		{
+			Name:        "es_bulk_ingest",
+			Description: "Stream NDJSON, JSON array or CSV documents into an index, inline or from a file on the server, and report throughput and failures",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"index": {
+						Type:        "string",
+						Description: "Index, alias or data stream to ingest into",
+					},
+					"data": {
+						Type:        "string",
+						Description: "Inline payload (use either 'data' or 'path')",
+					},
+					"path": {
+						Type:        "string",
+						Description: "File path or file:// URI inside the server's ingest directory (use either 'data' or 'path'); reading MCP resources is not supported, pass their content as 'data'",
+					},
+					"format": {
+						Type:        "string",
+						Description: "Payload format: ndjson (one document per line), json (array of documents) or csv (header row required) (default: ndjson)",
+						Enum:        []any{"ndjson", "json", "csv"},
+					},
+					"id_field": {
+						Type:        "string",
+						Description: "Document field to use as document ID (default: generated IDs)",
+					},
+					"op_type": {
+						Type:        "string",
+						Description: "Write operation: index or create (default: index)",
+						Enum:        []any{"index", "create"},
+					},
+					"pipeline": {
+						Type:        "string",
+						Description: "Ingest pipeline to run documents through",
+					},
+					"refresh": {
+						Type:        "string",
+						Description: "Refresh policy: true, false or wait_for (default: server configuration)",
+						Enum:        []any{"true", "false", "wait_for"},
+					},
+					"workers": {
+						Type:        "integer",
+						Description: "Number of concurrent bulk workers (default: number of CPUs)",
+					},
+					"flush_bytes": {
+						Type:        "integer",
+						Description: "Flush a bulk request once it reaches this size in bytes (default: 5MB)",
+					},
+					"flush_interval": {
+						Type:        "string",
+						Description: "Flush pending documents at least this often, e.g. 30s (default: 30s)",
+					},
+				},
+				Required: []string{"index"},
+			},
+		},
+	}
+}
+
+// Helper functions to create standardized MCP results
+
+// createErrorResult creates a standardized error result
+func createErrorResult(message string) mcp.CallToolResult {
+	return mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf("Error: %s", message),
+			},
+		},
+		IsError: true,
+	}
+}
+
+// createClientErrorResult creates an error result for a failed client call.
+// Elasticsearch error responses are also attached as structured content so
+// that callers can inspect the error type, reason and status code.
+func createClientErrorResult(message string, err error) mcp.CallToolResult {
+	result := createErrorResult(fmt.Sprintf("%s: %v", message, err))
+
+	var respErr *elasticsearch.ResponseError
+	if errors.As(err, &respErr) {
+		result.StructuredContent = map[string]interface{}{
+			"error":    respErr,
+			"conflict": errors.Is(err, elasticsearch.ErrVersionConflict),
+		}
+	}
+
+	return result
+}
+
+// createSuccessResult creates a standardized success result with structured data
+func createSuccessResult(text string, data interface{}) mcp.CallToolResult {
+	content := []mcp.Content{
+		&mcp.TextContent{
+			Text: text,
+		},
+	}
+
+	result := mcp.CallToolResult{
+		Content: content,
+		IsError: false,
+	}
+
+	if data != nil {
+		switch v := data.(type) {
+		case []interface{}:
+			result.StructuredContent = map[string]interface{}{
+				"data":  v,
+				"count": len(v),
+			}
+		case []string:
+			result.StructuredContent = map[string]interface{}{
+				"data":  v,
+				"count": len(v),
+			}
+		default:
+			result.StructuredContent = data
+		}
+	}
+
+	return result
+}
+
+// createSimpleSuccessResult creates a simple success result with only text
+func createSimpleSuccessResult(text string) mcp.CallToolResult {
+	return mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+		IsError: false,
+	}
+}
+
+// toStringSlice converts a JSON array argument into a slice of strings,
+// skipping any non-string elements
+func toStringSlice(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+// parseDocumentOptions parses the write options of the document tools: optimistic
+// concurrency control, refresh, routing, op_type and pipeline. Explicit versions
+// are rejected unless allowVersion is set, and 'op_type' and 'pipeline' unless
+// allowIndexing is set.
+func parseDocumentOptions(args map[string]interface{}, allowVersion, allowIndexing bool) (*elasticsearch.DocumentOptions, error) {
+	opts := &elasticsearch.DocumentOptions{}
+
+	if seqNo, ok := args["if_seq_no"].(float64); ok {
+		value := int(seqNo)
+		opts.IfSeqNo = &value
+	}
+	if primaryTerm, ok := args["if_primary_term"].(float64); ok {
+		value := int(primaryTerm)
+		opts.IfPrimaryTerm = &value
+	}
+	if (opts.IfSeqNo == nil) != (opts.IfPrimaryTerm == nil) {
+		return nil, fmt.Errorf("'if_seq_no' and 'if_primary_term' must be provided together")
+	}
+
+	refresh, err := parseRefresh(args)
+	if err != nil {
+		return nil, err
+	}
+	opts.Refresh = refresh
+
+	opts.Routing, _ = args["routing"].(string)
+	opts.Pipeline, _ = args["pipeline"].(string)
+	opts.OpType, _ = args["op_type"].(string)
+	if (opts.Pipeline != "" || opts.OpType != "") && !allowIndexing {
+		return nil, fmt.Errorf("'op_type' and 'pipeline' are only supported when indexing documents")
+	}
+	if opts.OpType != "" && opts.OpType != "index" && opts.OpType != "create" {
+		return nil, fmt.Errorf("'op_type' must be 'index' or 'create'")
+	}
+
+	version, hasVersion := args["version"].(float64)
+	versionType, _ := args["version_type"].(string)
+	if (hasVersion || versionType != "") && !allowVersion {
+		return nil, fmt.Errorf("'version' and 'version_type' are not supported by this tool, use 'if_seq_no' and 'if_primary_term'")
+	}
+	if hasVersion {
+		value := int(version)
+		opts.Version = &value
+	}
+	opts.VersionType = versionType
+
+	return opts, nil
+}
+
+// parseRefresh parses the optional refresh policy of a write tool.
+// Booleans are accepted as a convenience for "true" and "false".
+func parseRefresh(args map[string]interface{}) (string, error) {
+	switch v := args["refresh"].(type) {
+	case nil:
+		return "", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case string:
+		if v == "" || config.IsValidRefresh(v) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("'refresh' must be 'true', 'false' or 'wait_for'")
+}
+
+// toScript converts a script argument into a script object. A plain string
+// is treated as the script source.
+func toScript(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case string:
+		return map[string]interface{}{"source": v}
+	case map[string]interface{}:
+		return v
+	default:
+		return nil
+	}
+}
+
+// toSlices converts a slices argument into either a slice count or "auto"
+func toSlices(value interface{}) interface{} {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		return v
+	default:
+		return nil
+	}
+}
+
+// HandleTool handles MCP tool calls and routes them to appropriate handlers
+func (et *ElasticsearchTools) HandleTool(ctx context.Context, toolName string, arguments map[string]interface{}) mcp.CallToolResult {
+	switch toolName {
 	case "es_cluster_info":
 		return et.handleClusterInfo(ctx)
 	case "es_cluster_health":
@@ -316,283 +1722,2034 @@ func (et *ElasticsearchTools) HandleTool(ctx context.Context, toolName string, a
 		return et.handleIndexExists(ctx, arguments)
 	case "es_index_list":
 		return et.handleIndexList(ctx)
+	case "es_index_get_mapping":
+		return et.handleIndexGetMapping(ctx, arguments)
+	case "es_index_put_mapping":
+		return et.handleIndexPutMapping(ctx, arguments)
+	case "es_index_get_settings":
+		return et.handleIndexGetSettings(ctx, arguments)
+	case "es_index_put_settings":
+		return et.handleIndexPutSettings(ctx, arguments)
+	case "es_field_caps":
+		return et.handleFieldCaps(ctx, arguments)
+	case "es_data_stream_list":
+		return et.handleDataStreamList(ctx)
+	case "es_data_stream_get":
+		return et.handleDataStreamGet(ctx, arguments)
+	case "es_data_stream_create":
+		return et.handleDataStreamCreate(ctx, arguments)
+	case "es_data_stream_delete":
+		return et.handleDataStreamDelete(ctx, arguments)
+	case "es_rollover":
+		return et.handleRollover(ctx, arguments)
+	case "es_ilm_policy_list":
+		return et.handleILMPolicyList(ctx)
+	case "es_ilm_policy_get":
+		return et.handleILMPolicyGet(ctx, arguments)
+	case "es_ilm_policy_put":
+		return et.handleILMPolicyPut(ctx, arguments)
+	case "es_ilm_policy_delete":
+		return et.handleILMPolicyDelete(ctx, arguments)
+	case "es_ilm_explain":
+		return et.handleILMExplain(ctx, arguments)
+	case "es_alias_list":
+		return et.handleAliasList(ctx)
+	case "es_alias_get":
+		return et.handleAliasGet(ctx, arguments)
+	case "es_alias_update":
+		return et.handleAliasUpdate(ctx, arguments)
+	case "es_index_template_list":
+		return et.handleIndexTemplateList(ctx)
+	case "es_index_template_get":
+		return et.handleIndexTemplateGet(ctx, arguments)
+	case "es_index_template_put":
+		return et.handleIndexTemplatePut(ctx, arguments)
+	case "es_index_template_delete":
+		return et.handleIndexTemplateDelete(ctx, arguments)
+	case "es_index_template_simulate":
+		return et.handleIndexTemplateSimulate(ctx, arguments)
+	case "es_component_template_list":
+		return et.handleComponentTemplateList(ctx)
+	case "es_component_template_get":
+		return et.handleComponentTemplateGet(ctx, arguments)
+	case "es_component_template_put":
+		return et.handleComponentTemplatePut(ctx, arguments)
+	case "es_component_template_delete":
+		return et.handleComponentTemplateDelete(ctx, arguments)
 	case "es_document_index":
 		return et.handleDocumentIndex(ctx, arguments)
 	case "es_document_get":
 		return et.handleDocumentGet(ctx, arguments)
+	case "es_document_mget":
+		return et.handleDocumentMGet(ctx, arguments)
 	case "es_document_update":
 		return et.handleDocumentUpdate(ctx, arguments)
 	case "es_document_delete":
 		return et.handleDocumentDelete(ctx, arguments)
 	case "es_search":
 		return et.handleSearch(ctx, arguments)
+	case "es_count":
+		return et.handleCount(ctx, arguments)
+	case "es_pit_close":
+		return et.handlePitClose(ctx, arguments)
+	case "es_async_search_submit":
+		return et.handleAsyncSearchSubmit(ctx, arguments)
+	case "es_async_search_get":
+		return et.handleAsyncSearchGet(ctx, arguments)
+	case "es_async_search_status":
+		return et.handleAsyncSearchStatus(ctx, arguments)
+	case "es_async_search_delete":
+		return et.handleAsyncSearchDelete(ctx, arguments)
+	case "es_eql_search":
+		return et.handleEQLSearch(ctx, arguments)
+	case "es_eql_get":
+		return et.handleEQLGet(ctx, arguments)
+	case "es_sql":
+		return et.handleSQL(ctx, arguments)
+	case "es_sql_translate":
+		return et.handleSQLTranslate(ctx, arguments)
+	case "es_esql":
+		return et.handleESQL(ctx, arguments)
 	case "es_bulk":
 		return et.handleBulk(ctx, arguments)
+	case "es_bulk_ingest":
+		return et.handleBulkIngest(ctx, arguments)
+	case "es_reindex":
+		return et.handleReindex(ctx, arguments)
+	case "es_update_by_query":
+		return et.handleUpdateByQuery(ctx, arguments)
+	case "es_delete_by_query":
+		return et.handleDeleteByQuery(ctx, arguments)
+	case "es_task_get":
+		return et.handleTaskGet(ctx, arguments)
+	default:
+		return createErrorResult(fmt.Sprintf("Unknown tool: %s", toolName))
+	}
+}
+
+// Individual tool handlers
+func (et *ElasticsearchTools) handleClusterInfo(ctx context.Context) mcp.CallToolResult {
+	info, err := et.client.Info(ctx)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get cluster info: %v", err))
+	}
+	return createSuccessResult("Cluster info retrieved successfully", info)
+}
+
+func (et *ElasticsearchTools) handleClusterHealth(ctx context.Context) mcp.CallToolResult {
+	health, err := et.client.Health(ctx)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get cluster health: %v", err))
+	}
+
+	return createSuccessResult("Cluster health retrieved successfully", health)
+}
+
+func (et *ElasticsearchTools) handleIndexCreate(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	// Build the request body
+	body := make(map[string]interface{})
+	if settings, exists := args["settings"]; exists {
+		if settingsMap, ok := settings.(map[string]interface{}); ok {
+			body["settings"] = settingsMap
+		}
+	}
+	if mappings, exists := args["mappings"]; exists {
+		if mappingsMap, ok := mappings.(map[string]interface{}); ok {
+			body["mappings"] = mappingsMap
+		}
+	}
+
+	err := et.client.CreateIndex(ctx, index, body)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to create index: %v", err))
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Index '%s' created successfully", index))
+}
+
+func (et *ElasticsearchTools) handleIndexDelete(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	err := et.client.DeleteIndex(ctx, index)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to delete index: %v", err))
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Index '%s' deleted successfully", index))
+}
+
+func (et *ElasticsearchTools) handleIndexExists(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	exists, err := et.client.IndexExists(ctx, index)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to check index existence: %v", err))
+	}
+
+	result := map[string]interface{}{
+		"index":  index,
+		"exists": exists,
+	}
+
+	return createSuccessResult(fmt.Sprintf("Index '%s' exists: %t", index, exists), result)
+}
+
+func (et *ElasticsearchTools) handleIndexList(ctx context.Context) mcp.CallToolResult {
+	indices, err := et.client.ListIndices(ctx)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to list indices: %v", err))
+	}
+
+	result := map[string]interface{}{
+		"indices": indices,
+		"count":   len(indices),
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d indices", len(indices)), result)
+}
+
+func (et *ElasticsearchTools) handleIndexGetMapping(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	mappings, err := et.client.GetMapping(ctx, index)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get mapping: %v", err))
+	}
+
+	return createSuccessResult(fmt.Sprintf("Mapping for '%s' retrieved successfully", index), mappings)
+}
+
+func (et *ElasticsearchTools) handleIndexPutMapping(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	mappings, ok := args["mappings"].(map[string]interface{})
+	if !ok {
+		return createErrorResult("Missing or invalid 'mappings' parameter")
+	}
+
+	err := et.client.PutMapping(ctx, index, mappings)
+	if err != nil {
+		return createClientErrorResult("Failed to update mapping", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Mapping of '%s' updated successfully", index))
+}
+
+func (et *ElasticsearchTools) handleIndexGetSettings(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	includeDefaults, _ := args["include_defaults"].(bool) // Optional parameter
+
+	settings, err := et.client.GetSettings(ctx, index, includeDefaults)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get settings: %v", err))
+	}
+
+	return createSuccessResult(fmt.Sprintf("Settings for '%s' retrieved successfully", index), settings)
+}
+
+func (et *ElasticsearchTools) handleIndexPutSettings(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	settings, ok := args["settings"].(map[string]interface{})
+	if !ok {
+		return createErrorResult("Missing or invalid 'settings' parameter")
+	}
+
+	err := et.client.PutSettings(ctx, index, settings)
+	if err != nil {
+		return createClientErrorResult("Failed to update settings", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Settings of '%s' updated successfully", index))
+}
+
+func (et *ElasticsearchTools) handleFieldCaps(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	// Index is optional for field capabilities
+	index, _ := args["index"].(string)
+
+	fields := toStringSlice(args["fields"])
+	if len(fields) == 0 {
+		fields = []string{"*"}
+	}
+
+	result, err := et.client.FieldCaps(ctx, index, fields)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get field capabilities: %v", err))
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d fields", len(result.Fields)), result)
+}
+
+func (et *ElasticsearchTools) handleDataStreamList(ctx context.Context) mcp.CallToolResult {
+	dataStreams, err := et.client.GetDataStreams(ctx, "")
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to list data streams: %v", err))
+	}
+
+	// Only summarize each data stream; es_data_stream_get returns the backing indices
+	summaries := make([]map[string]interface{}, 0, len(dataStreams))
+	for _, dataStream := range dataStreams {
+		summary := map[string]interface{}{
+			"name":            dataStream.Name,
+			"generation":      dataStream.Generation,
+			"status":          dataStream.Status,
+			"template":        dataStream.Template,
+			"ilm_policy":      dataStream.IlmPolicy,
+			"backing_indices": len(dataStream.Indices),
+		}
+		if len(dataStream.Indices) > 0 {
+			summary["write_index"] = dataStream.Indices[len(dataStream.Indices)-1].IndexName
+		}
+		summaries = append(summaries, summary)
+	}
+
+	result := map[string]interface{}{
+		"data_streams": summaries,
+		"count":        len(summaries),
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d data streams", len(summaries)), result)
+}
+
+func (et *ElasticsearchTools) handleDataStreamGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	dataStreams, err := et.client.GetDataStreams(ctx, name)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get data stream: %v", err))
+	}
+
+	result := map[string]interface{}{
+		"data_streams": dataStreams,
+		"count":        len(dataStreams),
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d data streams", len(dataStreams)), result)
+}
+
+func (et *ElasticsearchTools) handleDataStreamCreate(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	err := et.client.CreateDataStream(ctx, name)
+	if err != nil {
+		return createClientErrorResult("Failed to create data stream", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Data stream '%s' created successfully", name))
+}
+
+func (et *ElasticsearchTools) handleDataStreamDelete(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	err := et.client.DeleteDataStream(ctx, name)
+	if err != nil {
+		return createClientErrorResult("Failed to delete data stream", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Data stream '%s' deleted successfully", name))
+}
+
+func (et *ElasticsearchTools) handleRollover(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	target, ok := args["target"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'target' parameter")
+	}
+
+	conditions, _ := args["conditions"].(map[string]interface{}) // Optional parameter
+	dryRun, _ := args["dry_run"].(bool)                          // Optional parameter
+
+	result, err := et.client.Rollover(ctx, target, conditions, dryRun)
+	if err != nil {
+		return createClientErrorResult("Failed to roll over", err)
+	}
+
+	if !result.RolledOver {
+		return createSuccessResult(fmt.Sprintf("'%s' was not rolled over", target), result)
+	}
+	return createSuccessResult(fmt.Sprintf("'%s' rolled over from '%s' to '%s'", target, result.OldIndex, result.NewIndex), result)
+}
+
+func (et *ElasticsearchTools) handleILMPolicyList(ctx context.Context) mcp.CallToolResult {
+	policies, err := et.client.GetLifecyclePolicies(ctx, "")
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to list lifecycle policies: %v", err))
+	}
+
+	result := map[string]interface{}{
+		"policies": policies,
+		"count":    len(policies),
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d lifecycle policies", len(policies)), result)
+}
+
+func (et *ElasticsearchTools) handleILMPolicyGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	policies, err := et.client.GetLifecyclePolicies(ctx, name)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get lifecycle policy: %v", err))
+	}
+
+	policy, ok := policies[name]
+	if !ok {
+		return createErrorResult(fmt.Sprintf("Failed to get lifecycle policy: lifecycle policy '%s' not found", name))
+	}
+
+	return createSuccessResult(fmt.Sprintf("Lifecycle policy '%s' retrieved successfully", name), policy)
+}
+
+func (et *ElasticsearchTools) handleILMPolicyPut(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	policy, ok := args["policy"].(map[string]interface{})
+	if !ok {
+		return createErrorResult("Missing or invalid 'policy' parameter")
+	}
+
+	err := et.client.PutLifecyclePolicy(ctx, name, policy)
+	if err != nil {
+		return createClientErrorResult("Failed to put lifecycle policy", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Lifecycle policy '%s' saved successfully", name))
+}
+
+func (et *ElasticsearchTools) handleILMPolicyDelete(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	err := et.client.DeleteLifecyclePolicy(ctx, name)
+	if err != nil {
+		return createClientErrorResult("Failed to delete lifecycle policy", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Lifecycle policy '%s' deleted successfully", name))
+}
+
+func (et *ElasticsearchTools) handleILMExplain(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	onlyErrors, _ := args["only_errors"].(bool) // Optional parameter
+
+	indices, err := et.client.ExplainLifecycle(ctx, index, onlyErrors)
+	if err != nil {
+		return createClientErrorResult("Failed to explain lifecycle", err)
+	}
+
+	failed := 0
+	for _, lifecycle := range indices {
+		if lifecycle.FailedStep != "" {
+			failed++
+		}
+	}
+
+	result := map[string]interface{}{
+		"indices": indices,
+		"count":   len(indices),
+		"failed":  failed,
+	}
+
+	return createSuccessResult(fmt.Sprintf("Explained lifecycle of %d indices, %d with failed steps", len(indices), failed), result)
+}
+
+func (et *ElasticsearchTools) handleAliasList(ctx context.Context) mcp.CallToolResult {
+	aliases, err := et.client.ListAliases(ctx)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to list aliases: %v", err))
+	}
+
+	result := map[string]interface{}{
+		"aliases": aliases,
+		"count":   len(aliases),
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d aliases", len(aliases)), result)
+}
+
+func (et *ElasticsearchTools) handleAliasGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	// Both alias and index are optional filters
+	alias, _ := args["alias"].(string)
+	index, _ := args["index"].(string)
+
+	result, err := et.client.GetAlias(ctx, index, alias)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get alias: %v", err))
+	}
+
+	return createSuccessResult("Alias retrieved successfully", result)
+}
+
+func (et *ElasticsearchTools) handleAliasUpdate(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	actions, ok := args["actions"].([]interface{})
+	if !ok || len(actions) == 0 {
+		return createErrorResult("Missing or invalid 'actions' parameter")
+	}
+
+	// Convert and validate every action before touching the cluster
+	aliasActions := make([]elasticsearch.AliasAction, len(actions))
+	for i, action := range actions {
+		actionMap, ok := action.(map[string]interface{})
+		if !ok {
+			return createErrorResult(fmt.Sprintf("Invalid alias action at position %d: expected an object", i))
+		}
+
+		actionBytes, err := json.Marshal(actionMap)
+		if err != nil {
+			return createErrorResult(fmt.Sprintf("Invalid alias action at position %d: %v", i, err))
+		}
+		if err := json.Unmarshal(actionBytes, &aliasActions[i]); err != nil {
+			return createErrorResult(fmt.Sprintf("Invalid alias action at position %d: %v", i, err))
+		}
+
+		aliasAction := aliasActions[i]
+		switch aliasAction.Action {
+		case "add", "remove":
+			if aliasAction.Alias == "" {
+				return createErrorResult(fmt.Sprintf("Invalid alias action at position %d: 'alias' is required for '%s'", i, aliasAction.Action))
+			}
+		case "remove_index":
+		default:
+			return createErrorResult(fmt.Sprintf("Invalid alias action at position %d: unsupported action '%s'", i, aliasAction.Action))
+		}
+		if aliasAction.Index == "" {
+			return createErrorResult(fmt.Sprintf("Invalid alias action at position %d: 'index' is required", i))
+		}
+	}
+
+	err := et.client.UpdateAliases(ctx, aliasActions)
+	if err != nil {
+		return createClientErrorResult("Failed to update aliases", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("%d alias actions applied successfully", len(aliasActions)))
+}
+
+func (et *ElasticsearchTools) handleIndexTemplateList(ctx context.Context) mcp.CallToolResult {
+	templates, err := et.client.GetIndexTemplates(ctx, "")
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to list index templates: %v", err))
+	}
+
+	// Only summarize each template; es_index_template_get returns the full definition
+	summaries := make([]map[string]interface{}, 0, len(templates))
+	for _, template := range templates {
+		summaries = append(summaries, map[string]interface{}{
+			"name":           template.Name,
+			"index_patterns": template.IndexTemplate["index_patterns"],
+			"priority":       template.IndexTemplate["priority"],
+			"composed_of":    template.IndexTemplate["composed_of"],
+			"data_stream":    template.IndexTemplate["data_stream"] != nil,
+		})
+	}
+
+	result := map[string]interface{}{
+		"templates": summaries,
+		"count":     len(summaries),
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d index templates", len(summaries)), result)
+}
+
+func (et *ElasticsearchTools) handleIndexTemplateGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	templates, err := et.client.GetIndexTemplates(ctx, name)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get index template: %v", err))
+	}
+
+	result := map[string]interface{}{
+		"templates": templates,
+		"count":     len(templates),
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d index templates", len(templates)), result)
+}
+
+func (et *ElasticsearchTools) handleIndexTemplatePut(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	body, ok := args["body"].(map[string]interface{})
+	if !ok {
+		return createErrorResult("Missing or invalid 'body' parameter")
+	}
+
+	err := et.client.PutIndexTemplate(ctx, name, body)
+	if err != nil {
+		return createClientErrorResult("Failed to put index template", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Index template '%s' saved successfully", name))
+}
+
+func (et *ElasticsearchTools) handleIndexTemplateDelete(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	err := et.client.DeleteIndexTemplate(ctx, name)
+	if err != nil {
+		return createClientErrorResult("Failed to delete index template", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Index template '%s' deleted successfully", name))
+}
+
+func (et *ElasticsearchTools) handleIndexTemplateSimulate(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	result, err := et.client.SimulateIndexTemplate(ctx, index)
+	if err != nil {
+		return createClientErrorResult("Failed to simulate index template", err)
+	}
+
+	return createSuccessResult(fmt.Sprintf("Simulated templates for index '%s'", index), result)
+}
+
+func (et *ElasticsearchTools) handleComponentTemplateList(ctx context.Context) mcp.CallToolResult {
+	templates, err := et.client.GetComponentTemplates(ctx, "")
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to list component templates: %v", err))
+	}
+
+	// Only summarize each template; es_component_template_get returns the full definition
+	summaries := make([]map[string]interface{}, 0, len(templates))
+	for _, template := range templates {
+		summaries = append(summaries, map[string]interface{}{
+			"name":    template.Name,
+			"version": template.ComponentTemplate["version"],
+			"_meta":   template.ComponentTemplate["_meta"],
+		})
+	}
+
+	result := map[string]interface{}{
+		"templates": summaries,
+		"count":     len(summaries),
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d component templates", len(summaries)), result)
+}
+
+func (et *ElasticsearchTools) handleComponentTemplateGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	templates, err := et.client.GetComponentTemplates(ctx, name)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get component template: %v", err))
+	}
+
+	result := map[string]interface{}{
+		"templates": templates,
+		"count":     len(templates),
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d component templates", len(templates)), result)
+}
+
+func (et *ElasticsearchTools) handleComponentTemplatePut(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	body, ok := args["body"].(map[string]interface{})
+	if !ok {
+		return createErrorResult("Missing or invalid 'body' parameter")
+	}
+
+	err := et.client.PutComponentTemplate(ctx, name, body)
+	if err != nil {
+		return createClientErrorResult("Failed to put component template", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Component template '%s' saved successfully", name))
+}
+
+func (et *ElasticsearchTools) handleComponentTemplateDelete(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	name, ok := args["name"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'name' parameter")
+	}
+
+	err := et.client.DeleteComponentTemplate(ctx, name)
+	if err != nil {
+		return createClientErrorResult("Failed to delete component template", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Component template '%s' deleted successfully", name))
+}
+
+func (et *ElasticsearchTools) handleDocumentIndex(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	body, ok := args["body"].(map[string]interface{})
+	if !ok {
+		return createErrorResult("Missing or invalid 'body' parameter")
+	}
+
+	id, _ := args["id"].(string) // Optional parameter
+
+	opts, err := parseDocumentOptions(args, true, true)
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+
+	result, err := et.client.Index(ctx, index, id, body, opts)
+	if err != nil {
+		return createClientErrorResult("Failed to index document", err)
+	}
+
+	return createSuccessResult("Document indexed successfully", result)
+}
+
+func (et *ElasticsearchTools) handleDocumentGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	id, ok := args["id"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'id' parameter")
+	}
+
+	routing, _ := args["routing"].(string) // Optional parameter
+
+	result, err := et.client.Get(ctx, index, id, &elasticsearch.DocumentOptions{Routing: routing})
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to get document: %v", err))
+	}
+
+	return createSuccessResult("Document retrieved successfully", result)
+}
+
+func (et *ElasticsearchTools) handleDocumentMGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	docs, ok := args["docs"].([]interface{})
+	if !ok || len(docs) == 0 {
+		return createErrorResult("Missing or invalid 'docs' parameter")
+	}
+
+	mgetDocs := make([]elasticsearch.MGetDoc, len(docs))
+	for i, doc := range docs {
+		docMap, ok := doc.(map[string]interface{})
+		if !ok {
+			return createErrorResult(fmt.Sprintf("Invalid document at position %d: expected an object", i))
+		}
+
+		index, _ := docMap["index"].(string)
+		id, _ := docMap["id"].(string)
+		if index == "" || id == "" {
+			return createErrorResult(fmt.Sprintf("Invalid document at position %d: 'index' and 'id' are required", i))
+		}
+
+		mgetDocs[i] = elasticsearch.MGetDoc{
+			Index:  index,
+			ID:     id,
+			Source: docMap["_source"],
+		}
+		mgetDocs[i].Routing, _ = docMap["routing"].(string)
+	}
+
+	items, err := et.client.MGet(ctx, mgetDocs)
+	if err != nil {
+		return createClientErrorResult("Failed to get documents", err)
+	}
+
+	found := 0
+	for _, item := range items {
+		if item.Found {
+			found++
+		}
+	}
+
+	result := map[string]interface{}{
+		"docs":      items,
+		"found":     found,
+		"not_found": len(items) - found,
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d of %d documents", found, len(items)), result)
+}
+
+func (et *ElasticsearchTools) handleDocumentUpdate(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	id, ok := args["id"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'id' parameter")
+	}
+
+	updateRequest, err := parseUpdateRequest(args)
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+
+	opts, err := parseDocumentOptions(args, false, false)
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+
+	result, err := et.client.Update(ctx, index, id, updateRequest, opts)
+	if err != nil {
+		return createClientErrorResult("Failed to update document", err)
+	}
+
+	return createSuccessResult(fmt.Sprintf("Document '%s' %s in index '%s' (version %d)", id, result.Result, index, result.Version), result)
+}
+
+// parseUpdateRequest parses the update arguments of es_document_update.
+// The deprecated 'body' parameter is either a full update body or a partial
+// document, and cannot be combined with the parameters it replaces.
+func parseUpdateRequest(args map[string]interface{}) (*elasticsearch.UpdateRequest, error) {
+	params := args
+	fullBody := false
+	if body, ok := args["body"].(map[string]interface{}); ok {
+		for _, field := range []string{"doc", "script", "upsert", "doc_as_upsert", "scripted_upsert"} {
+			if _, ok := args[field]; ok {
+				return nil, fmt.Errorf("'%s' cannot be combined with the deprecated 'body' parameter", field)
+			}
+		}
+
+		_, hasDoc := body["doc"]
+		_, hasScript := body["script"]
+		if fullBody = hasDoc || hasScript; fullBody {
+			params = body
+		} else {
+			params = map[string]interface{}{"doc": body}
+		}
+	}
+
+	updateRequest := &elasticsearch.UpdateRequest{
+		Script: toScript(params["script"]),
+	}
+	updateRequest.Doc, _ = params["doc"].(map[string]interface{})
+	updateRequest.Upsert, _ = params["upsert"].(map[string]interface{})
+	updateRequest.DocAsUpsert, _ = params["doc_as_upsert"].(bool)
+	updateRequest.ScriptedUpsert, _ = params["scripted_upsert"].(bool)
+
+	// retry_on_conflict is a request parameter, but older callers put it in the body
+	retries, hasRetries := args["retry_on_conflict"]
+	if bodyRetries, ok := params["retry_on_conflict"]; ok && fullBody {
+		if hasRetries {
+			return nil, fmt.Errorf("'retry_on_conflict' cannot be provided both in 'body' and as a parameter")
+		}
+		retries, hasRetries = bodyRetries, true
+	}
+	if hasRetries {
+		value, ok := retries.(float64)
+		if !ok {
+			return nil, fmt.Errorf("'retry_on_conflict' must be an integer")
+		}
+		updateRequest.RetryOnConflict = int(value)
+	}
+
+	if updateRequest.Doc == nil && updateRequest.Script == nil {
+		return nil, fmt.Errorf("either 'doc' or 'script' is required")
+	}
+	if updateRequest.Doc != nil && updateRequest.Script != nil {
+		return nil, fmt.Errorf("only one of 'doc' and 'script' may be provided")
+	}
+
+	return updateRequest, nil
+}
+
+func (et *ElasticsearchTools) handleDocumentDelete(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	index, ok := args["index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'index' parameter")
+	}
+
+	id, ok := args["id"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'id' parameter")
+	}
+
+	opts, err := parseDocumentOptions(args, true, false)
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+
+	err = et.client.Delete(ctx, index, id, opts)
+	if err != nil {
+		return createClientErrorResult("Failed to delete document", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Document '%s' deleted successfully from index '%s'", id, index))
+}
+
+func (et *ElasticsearchTools) handleSearch(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	searchRequest, err := parseSearchRequest(args)
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+	queryHash, err := searchQueryHash(searchRequest)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to serialize search request: %v", err))
+	}
+
+	// Resume from a cursor, or open a new point in time if requested
+	openedPIT := false
+	if c, ok := args["cursor"].(string); ok && c != "" {
+		cursor, err := decodeSearchCursor(c)
+		if err != nil {
+			return createErrorResult(fmt.Sprintf("Invalid 'cursor' parameter: %v", err))
+		}
+		// The sort values of the cursor only make sense for the same query and sort
+		if cursor.QueryHash != queryHash {
+			return createErrorResult("Invalid 'cursor' parameter: the cursor belongs to a search with a different query or sort, re-send the 'query', 'sort', 'knn' and 'rank' of the first call")
+		}
+		searchRequest.PIT = &elasticsearch.PointInTime{ID: cursor.PitID, KeepAlive: cursor.KeepAlive}
+		searchRequest.SearchAfter = cursor.SearchAfter
+		searchRequest.From = 0
+	} else if keepAlive, ok := args["pit_keep_alive"].(string); ok && keepAlive != "" {
+		if searchRequest.Index == "" {
+			return createErrorResult("'index' is required when 'pit_keep_alive' is set")
+		}
+		pitID, err := et.client.OpenPointInTime(ctx, searchRequest.Index, keepAlive)
+		if err != nil {
+			return createErrorResult(fmt.Sprintf("Failed to open point in time: %v", err))
+		}
+		searchRequest.PIT = &elasticsearch.PointInTime{ID: pitID, KeepAlive: keepAlive}
+		openedPIT = true
+	}
+
+	result, err := et.client.Search(ctx, searchRequest)
+	if err != nil {
+		if openedPIT {
+			et.closePointInTime(ctx, searchRequest.PIT.ID)
+		}
+		if searchRequest.Rank != nil {
+			if info, infoErr := et.client.Info(ctx); infoErr == nil && !versionAtLeast(info.Version.Number, 8, 8) {
+				return createErrorResult(fmt.Sprintf("'rank' requires Elasticsearch 8.8 or later, the cluster is running %s; omit it to sum query and knn scores", info.Version.Number))
+			}
+		}
+		return createErrorResult(fmt.Sprintf("Failed to execute search: %v", err))
+	}
+
+	if searchRequest.PIT == nil {
+		return createSuccessResult("Search executed successfully", result)
+	}
+
+	// Elasticsearch may hand back a refreshed PIT ID with every page
+	pitID := searchRequest.PIT.ID
+	if result.PitID != "" {
+		pitID = result.PitID
+	}
+
+	// A full page means there may be more hits; otherwise the PIT is no longer needed
+	page := searchPage{SearchResponse: result}
+	hits := result.Hits.Hits
+	if size := searchRequest.Size; size > 0 && len(hits) == size && len(hits[len(hits)-1].Sort) > 0 {
+		next, err := encodeSearchCursor(searchCursor{
+			PitID:       pitID,
+			KeepAlive:   searchRequest.PIT.KeepAlive,
+			SearchAfter: hits[len(hits)-1].Sort,
+			QueryHash:   queryHash,
+		})
+		if err != nil {
+			return createErrorResult(fmt.Sprintf("Failed to build cursor: %v", err))
+		}
+		page.Cursor = next
+		return createSuccessResult("Search executed successfully, pass 'cursor' to fetch the next page", page)
+	}
+
+	et.closePointInTime(ctx, pitID)
+	return createSuccessResult("Search executed successfully, no more pages", page)
+}
+
+// parseSearchRequest parses the query arguments shared by es_search and es_async_search_submit
+func parseSearchRequest(args map[string]interface{}) (*elasticsearch.SearchRequest, error) {
+	// Index is optional for search
+	index, _ := args["index"].(string)
+
+	// Parse kNN clauses, either a single clause or a list of them
+	knn := args["knn"]
+	if knn != nil {
+		if err := validateKnn(knn); err != nil {
+			return nil, fmt.Errorf("Invalid 'knn' parameter: %v", err)
+		}
+	}
+	rank, _ := args["rank"].(map[string]interface{})
+
+	// Default query if none provided (this should be the query content, not wrapped in "query").
+	// A pure kNN search has no query, otherwise match_all would turn it into a hybrid search.
+	var query map[string]interface{}
+	if knn == nil {
+		query = map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		}
+	}
+	if q, exists := args["query"]; exists {
+		if queryMap, ok := q.(map[string]interface{}); ok {
+			query = queryMap
+		}
+	}
+	if rank != nil && (knn == nil || query == nil) {
+		return nil, fmt.Errorf("'rank' requires both 'query' and 'knn'")
+	}
+
+	// Default size
+	size := 10
+	if s, exists := args["size"]; exists {
+		if sizeInt, ok := s.(float64); ok {
+			size = int(sizeInt)
+		}
+	}
+
+	// Default from
+	from := 0
+	if f, exists := args["from"]; exists {
+		if fromInt, ok := f.(float64); ok {
+			from = int(fromInt)
+		}
+	}
+
+	// Parse sort parameter
+	var sort []interface{}
+	if s, exists := args["sort"]; exists {
+		if sortArray, ok := s.([]interface{}); ok {
+			sort = sortArray
+		}
+	}
+
+	// Parse _source parameter
+	var source interface{}
+	if src, exists := args["_source"]; exists {
+		source = src
+	}
+
+	// Parse aggregations, accepting both the short and the long form
+	var aggs map[string]interface{}
+	if a, exists := args["aggs"]; exists {
+		if aggsMap, ok := a.(map[string]interface{}); ok {
+			aggs = aggsMap
+		}
+	} else if a, exists := args["aggregations"]; exists {
+		if aggsMap, ok := a.(map[string]interface{}); ok {
+			aggs = aggsMap
+		}
+	}
+
+	// Parse highlighting, fields and explain options
+	highlight, _ := args["highlight"].(map[string]interface{})
+	fields, _ := args["fields"].([]interface{})
+	explain, _ := args["explain"].(bool)
+	namedQueriesScore, _ := args["include_named_queries_score"].(bool)
+
+	// Parse search_after parameter
+	var searchAfter []interface{}
+	if sa, exists := args["search_after"]; exists {
+		if saArray, ok := sa.([]interface{}); ok {
+			searchAfter = saArray
+		}
+	}
+
+	return &elasticsearch.SearchRequest{
+		Index:       index,
+		Query:       query,
+		Size:        size,
+		From:        from,
+		Sort:        sort,
+		Source:      source,
+		Aggs:        aggs,
+		Highlight:   highlight,
+		Fields:      fields,
+		Explain:     explain,
+		SearchAfter: searchAfter,
+		Knn:         knn,
+		Rank:        rank,
+
+		IncludeNamedQueriesScore: namedQueriesScore,
+	}, nil
+}
+
+// validateKnn checks that a knn argument is a clause or a non-empty list of
+// clauses, each with a field and a query vector or query vector builder
+func validateKnn(value interface{}) error {
+	var clauses []interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		clauses = []interface{}{v}
+	case []interface{}:
+		if len(v) == 0 {
+			return fmt.Errorf("at least one clause is required")
+		}
+		clauses = v
 	default:
-		return createErrorResult(fmt.Sprintf("Unknown tool: %s", toolName))
+		return fmt.Errorf("must be an object or an array of objects")
+	}
+
+	for i, clause := range clauses {
+		clauseMap, ok := clause.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("clause %d must be an object", i)
+		}
+		if field, _ := clauseMap["field"].(string); field == "" {
+			return fmt.Errorf("clause %d has no 'field'", i)
+		}
+		_, hasVector := clauseMap["query_vector"]
+		_, hasBuilder := clauseMap["query_vector_builder"]
+		if !hasVector && !hasBuilder {
+			return fmt.Errorf("clause %d needs 'query_vector' or 'query_vector_builder'", i)
+		}
+	}
+	return nil
+}
+
+func (et *ElasticsearchTools) handleCount(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	// Index and query are both optional for count
+	index, _ := args["index"].(string)
+	query, _ := args["query"].(map[string]interface{})
+
+	result, err := et.client.Count(ctx, index, query)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to count documents: %v", err))
+	}
+
+	return createSuccessResult(fmt.Sprintf("Found %d matching documents", result.Count), result)
+}
+
+func (et *ElasticsearchTools) handlePitClose(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	c, ok := args["cursor"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'cursor' parameter")
+	}
+
+	cursor, err := decodeSearchCursor(c)
+	if err != nil {
+		return createErrorResult(fmt.Sprintf("Invalid 'cursor' parameter: %v", err))
+	}
+
+	if err := et.client.ClosePointInTime(ctx, cursor.PitID); err != nil {
+		return createErrorResult(fmt.Sprintf("Failed to close point in time: %v", err))
+	}
+
+	return createSimpleSuccessResult("Point in time closed successfully")
+}
+
+// closePointInTime releases a point in time on a best-effort basis; it expires
+// on its own after keep_alive, so failures are only logged.
+func (et *ElasticsearchTools) closePointInTime(ctx context.Context, pitID string) {
+	if err := et.client.ClosePointInTime(ctx, pitID); err != nil {
+		log.Printf("Failed to close point in time: %v", err)
+	}
+}
+
+// searchPage is a search response extended with the cursor for the next page
+type searchPage struct {
+	*elasticsearch.SearchResponse
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// searchCursor is the decoded form of the opaque cursor returned by es_search
+type searchCursor struct {
+	PitID       string        `json:"pit_id"`
+	KeepAlive   string        `json:"keep_alive,omitempty"`
+	SearchAfter []interface{} `json:"search_after"`
+	// QueryHash identifies the query and sort the cursor was created for
+	QueryHash string `json:"query_hash"`
+}
+
+// searchQueryHash hashes the parts of a search request that determine the order
+// of the hits, so that a cursor is not resumed with a different query or sort
+func searchQueryHash(req *elasticsearch.SearchRequest) (string, error) {
+	data, err := json.Marshal(struct {
+		Query map[string]interface{} `json:"query,omitempty"`
+		Sort  []interface{}          `json:"sort,omitempty"`
+		Knn   interface{}            `json:"knn,omitempty"`
+		Rank  map[string]interface{} `json:"rank,omitempty"`
+	}{req.Query, req.Sort, req.Knn, req.Rank})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// encodeSearchCursor serializes a cursor into an opaque URL-safe string
+func encodeSearchCursor(cursor searchCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeSearchCursor parses a cursor produced by encodeSearchCursor
+func decodeSearchCursor(value string) (*searchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", err)
+	}
+
+	// Keep sort values as json.Number so large longs survive the round-trip
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var cursor searchCursor
+	if err := decoder.Decode(&cursor); err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", err)
+	}
+	if cursor.PitID == "" {
+		return nil, fmt.Errorf("cursor has no point in time")
+	}
+
+	return &cursor, nil
+}
+
+func (et *ElasticsearchTools) handleAsyncSearchSubmit(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	searchRequest, err := parseSearchRequest(args)
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+	if len(searchRequest.SearchAfter) > 0 || args["cursor"] != nil || args["pit_keep_alive"] != nil {
+		return createErrorResult("Async search does not support 'search_after', 'cursor' or 'pit_keep_alive', use 'from' and 'size' or es_search")
+	}
+
+	wait := time.Second
+	if _, ok := args["wait_for_completion_timeout"]; ok {
+		if wait, err = parseDuration(args, "wait_for_completion_timeout"); err != nil {
+			return createErrorResult(err.Error())
+		}
+	}
+	keepAlive, err := parseDuration(args, "keep_alive")
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+
+	// Wait in short steps so that progress can be reported while the search
+	// runs and no single request runs into the HTTP timeout
+	deadline := time.Now().Add(wait)
+	result, err := et.client.AsyncSearchSubmit(ctx, searchRequest, &elasticsearch.AsyncSearchOptions{
+		WaitForCompletionTimeout: asyncSearchWait(wait),
+		KeepAlive:                keepAlive,
+	})
+	if err != nil {
+		return createClientErrorResult("Failed to submit async search", err)
+	}
+
+	report := progressReporterFrom(ctx)
+	for result.IsRunning {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+
+		if status, err := et.client.AsyncSearchStatus(ctx, result.ID); err == nil {
+			shards := status.Shards
+			done := shards.Successful + shards.Skipped + shards.Failed
+			report(float64(done), float64(shards.Total), fmt.Sprintf("%d of %d shards searched", done, shards.Total))
+		}
+
+		result, err = et.client.AsyncSearchGet(ctx, result.ID, &elasticsearch.AsyncSearchOptions{
+			WaitForCompletionTimeout: asyncSearchWait(remaining),
+		})
+		if err != nil {
+			return createClientErrorResult("Failed to get async search", err)
+		}
+	}
+
+	return createAsyncSearchResult(result)
+}
+
+// asyncSearchWait limits a wait for completion timeout to the poll interval.
+// It is at least 1ms, since a zero timeout is omitted and Elasticsearch then
+// waits its default of 1s instead.
+func asyncSearchWait(remaining time.Duration) time.Duration {
+	return max(min(remaining, asyncSearchPollInterval), time.Millisecond)
+}
+
+func (et *ElasticsearchTools) handleAsyncSearchGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	id, ok := args["id"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'id' parameter")
+	}
+
+	opts := &elasticsearch.AsyncSearchOptions{}
+	var err error
+	if opts.WaitForCompletionTimeout, err = parseDuration(args, "wait_for_completion_timeout"); err != nil {
+		return createErrorResult(err.Error())
+	}
+	if opts.KeepAlive, err = parseDuration(args, "keep_alive"); err != nil {
+		return createErrorResult(err.Error())
+	}
+
+	result, err := et.client.AsyncSearchGet(ctx, id, opts)
+	if err != nil {
+		return createClientErrorResult("Failed to get async search", err)
+	}
+
+	return createAsyncSearchResult(result)
+}
+
+func (et *ElasticsearchTools) handleAsyncSearchStatus(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	id, ok := args["id"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'id' parameter")
+	}
+
+	result, err := et.client.AsyncSearchStatus(ctx, id)
+	if err != nil {
+		return createClientErrorResult("Failed to get async search status", err)
+	}
+
+	if result.IsRunning {
+		shards := result.Shards
+		return createSuccessResult(fmt.Sprintf("Async search '%s' is running: %d of %d shards searched",
+			id, shards.Successful+shards.Skipped+shards.Failed, shards.Total), result)
+	}
+	return createSuccessResult(fmt.Sprintf("Async search '%s' completed with status %d", id, result.CompletionStatus), result)
+}
+
+func (et *ElasticsearchTools) handleAsyncSearchDelete(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	id, ok := args["id"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'id' parameter")
+	}
+
+	if err := et.client.AsyncSearchDelete(ctx, id); err != nil {
+		return createClientErrorResult("Failed to delete async search", err)
+	}
+
+	return createSimpleSuccessResult(fmt.Sprintf("Async search '%s' deleted successfully", id))
+}
+
+// createAsyncSearchResult summarizes an async search response, pointing to
+// es_async_search_get while the search is running
+func createAsyncSearchResult(result *elasticsearch.AsyncSearchResponse) mcp.CallToolResult {
+	if result.IsRunning {
+		return createSuccessResult(fmt.Sprintf("Async search is still running, partial results returned; poll it with es_async_search_get using id '%s'", result.ID), result)
+	}
+	if result.Response == nil {
+		return createSuccessResult("Async search completed", result)
+	}
+	if result.ID == "" {
+		return createSuccessResult(fmt.Sprintf("Async search completed with %d hits", result.Response.Hits.Total.Value), result)
+	}
+	return createSuccessResult(fmt.Sprintf("Async search completed with %d hits, results are kept under id '%s'", result.Response.Hits.Total.Value, result.ID), result)
+}
+
+func (et *ElasticsearchTools) handleEQLSearch(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	eqlRequest, err := parseEQLSearchRequest(args)
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+
+	result, err := et.client.EQLSearch(ctx, eqlRequest)
+	if err != nil {
+		return createClientErrorResult("Failed to execute EQL search", err)
+	}
+
+	return createEQLResult(result)
+}
+
+// parseEQLSearchRequest parses the arguments of es_eql_search. Unless a timeout
+// is given, searches that take longer than eqlWaitForCompletionTimeout continue
+// asynchronously.
+func parseEQLSearchRequest(args map[string]interface{}) (*elasticsearch.EQLSearchRequest, error) {
+	index, ok := args["index"].(string)
+	if !ok {
+		return nil, fmt.Errorf("Missing or invalid 'index' parameter")
+	}
+
+	query, ok := args["query"].(string)
+	if !ok || query == "" {
+		return nil, fmt.Errorf("Missing or invalid 'query' parameter")
+	}
+
+	eqlRequest := &elasticsearch.EQLSearchRequest{
+		Index: index,
+		Query: query,
+	}
+	eqlRequest.TimestampField, _ = args["timestamp_field"].(string)
+	eqlRequest.EventCategoryField, _ = args["event_category_field"].(string)
+	eqlRequest.TiebreakerField, _ = args["tiebreaker_field"].(string)
+	eqlRequest.Filter, _ = args["filter"].(map[string]interface{})
+	if size, ok := args["size"].(float64); ok {
+		eqlRequest.Size = int(size)
+	}
+	if fetchSize, ok := args["fetch_size"].(float64); ok {
+		eqlRequest.FetchSize = int(fetchSize)
+	}
+
+	var err error
+	if eqlRequest.WaitForCompletionTimeout, err = parseDuration(args, "wait_for_completion_timeout"); err != nil {
+		return nil, err
 	}
+	if eqlRequest.WaitForCompletionTimeout == 0 {
+		eqlRequest.WaitForCompletionTimeout = eqlWaitForCompletionTimeout
+	}
+	if eqlRequest.KeepAlive, err = parseDuration(args, "keep_alive"); err != nil {
+		return nil, err
+	}
+
+	return eqlRequest, nil
 }
 
-// Individual tool handlers
-func (et *ElasticsearchTools) handleClusterInfo(ctx context.Context) mcp.CallToolResult {
-	info, err := et.client.Info(ctx)
+func (et *ElasticsearchTools) handleEQLGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	id, ok := args["id"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'id' parameter")
+	}
+
+	timeout, err := parseDuration(args, "wait_for_completion_timeout")
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to get cluster info: %v", err))
+		return createErrorResult(err.Error())
 	}
-	return createSuccessResult("Cluster info retrieved successfully", info)
-}
 
-func (et *ElasticsearchTools) handleClusterHealth(ctx context.Context) mcp.CallToolResult {
-	health, err := et.client.Health(ctx)
+	result, err := et.client.EQLGet(ctx, id, timeout)
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to get cluster health: %v", err))
+		return createClientErrorResult("Failed to get EQL search", err)
 	}
 
-	return createSuccessResult("Cluster health retrieved successfully", health)
+	return createEQLResult(result)
 }
 
-func (et *ElasticsearchTools) handleIndexCreate(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
-	index, ok := args["index"].(string)
-	if !ok {
-		return createErrorResult("Missing or invalid 'index' parameter")
+// createEQLResult summarizes an EQL response, pointing to es_eql_get while the search is running
+func createEQLResult(result *elasticsearch.EQLResponse) mcp.CallToolResult {
+	if result.IsRunning {
+		return createSuccessResult(fmt.Sprintf("EQL search is still running, poll it with es_eql_get using id '%s'", result.ID), result)
 	}
+	if len(result.Hits.Sequences) > 0 {
+		return createSuccessResult(fmt.Sprintf("Found %d sequences", len(result.Hits.Sequences)), result)
+	}
+	return createSuccessResult(fmt.Sprintf("Found %d events", len(result.Hits.Events)), result)
+}
 
-	// Build the request body
-	body := make(map[string]interface{})
-	if settings, exists := args["settings"]; exists {
-		if settingsMap, ok := settings.(map[string]interface{}); ok {
-			body["settings"] = settingsMap
-		}
+// parseDuration parses an optional duration argument such as "30s"
+func parseDuration(args map[string]interface{}, name string) (time.Duration, error) {
+	value, ok := args[name].(string)
+	if !ok || value == "" {
+		return 0, nil
 	}
-	if mappings, exists := args["mappings"]; exists {
-		if mappingsMap, ok := mappings.(map[string]interface{}); ok {
-			body["mappings"] = mappingsMap
-		}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid '%s' parameter: %v", name, err)
 	}
+	return duration, nil
+}
 
-	err := et.client.CreateIndex(ctx, index, body)
+func (et *ElasticsearchTools) handleSQL(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	sqlRequest := parseSQLRequest(args)
+	cursor, _ := args["cursor"].(string)
+	if (sqlRequest.Query == "") == (cursor == "") {
+		return createErrorResult("Exactly one of 'query' and 'cursor' parameters is required")
+	}
+
+	// The cursor carries the settings of the original query
+	if cursor != "" {
+		sqlRequest = &elasticsearch.SQLRequest{Cursor: cursor}
+	}
+
+	result, err := et.client.SQL(ctx, sqlRequest)
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to create index: %v", err))
+		return createClientErrorResult("Failed to execute SQL query", err)
 	}
 
-	return createSimpleSuccessResult(fmt.Sprintf("Index '%s' created successfully", index))
+	if result.Cursor != "" {
+		return createSuccessResult(fmt.Sprintf("Returned %d rows, pass 'cursor' to fetch the next page", len(result.Rows)), result)
+	}
+	return createSuccessResult(fmt.Sprintf("Returned %d rows, no more pages", len(result.Rows)), result)
 }
 
-func (et *ElasticsearchTools) handleIndexDelete(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
-	index, ok := args["index"].(string)
-	if !ok {
-		return createErrorResult("Missing or invalid 'index' parameter")
+func (et *ElasticsearchTools) handleSQLTranslate(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	sqlRequest := parseSQLRequest(args)
+	if sqlRequest.Query == "" {
+		return createErrorResult("Missing or invalid 'query' parameter")
 	}
 
-	err := et.client.DeleteIndex(ctx, index)
+	result, err := et.client.SQLTranslate(ctx, sqlRequest)
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to delete index: %v", err))
+		return createClientErrorResult("Failed to translate SQL query", err)
 	}
 
-	return createSimpleSuccessResult(fmt.Sprintf("Index '%s' deleted successfully", index))
+	return createSuccessResult("SQL query translated successfully", result)
 }
 
-func (et *ElasticsearchTools) handleIndexExists(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
-	index, ok := args["index"].(string)
-	if !ok {
-		return createErrorResult("Missing or invalid 'index' parameter")
+func (et *ElasticsearchTools) handleESQL(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	query, ok := args["query"].(string)
+	if !ok || query == "" {
+		return createErrorResult("Missing or invalid 'query' parameter")
 	}
 
-	exists, err := et.client.IndexExists(ctx, index)
-	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to check index existence: %v", err))
+	esqlRequest := &elasticsearch.ESQLRequest{
+		Query: query,
 	}
+	esqlRequest.Params, _ = args["params"].([]interface{})
+	esqlRequest.Filter, _ = args["filter"].(map[string]interface{})
 
-	result := map[string]interface{}{
-		"index":  index,
-		"exists": exists,
+	result, err := et.client.ESQL(ctx, esqlRequest)
+	if err != nil {
+		// Older clusters reject the endpoint itself, which is hard to tell from the raw error
+		if info, infoErr := et.client.Info(ctx); infoErr == nil && !versionAtLeast(info.Version.Number, 8, 11) {
+			return createErrorResult(fmt.Sprintf("ES|QL requires Elasticsearch 8.11 or later, the cluster is running %s; use es_sql or es_search instead", info.Version.Number))
+		}
+		return createClientErrorResult("Failed to execute ES|QL query", err)
 	}
 
-	return createSuccessResult(fmt.Sprintf("Index '%s' exists: %t", index, exists), result)
+	return createSuccessResult(fmt.Sprintf("Returned %d rows with %d columns", len(result.Values), len(result.Columns)), result)
 }
 
-func (et *ElasticsearchTools) handleIndexList(ctx context.Context) mcp.CallToolResult {
-	indices, err := et.client.ListIndices(ctx)
+// versionAtLeast reports whether a version number such as "8.11.1" is at
+// least major.minor. Unparsable versions are assumed to be recent enough.
+func versionAtLeast(version string, major, minor int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	gotMajor, err := strconv.Atoi(parts[0])
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to list indices: %v", err))
+		return true
+	}
+	if gotMajor != major {
+		return gotMajor > major
+	}
+	if len(parts) < 2 {
+		return true
 	}
 
-	result := map[string]interface{}{
-		"indices": indices,
-		"count":   len(indices),
+	gotMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return true
 	}
+	return gotMinor >= minor
+}
 
-	return createSuccessResult(fmt.Sprintf("Found %d indices", len(indices)), result)
+// parseSQLRequest parses the query arguments shared by es_sql and es_sql_translate
+func parseSQLRequest(args map[string]interface{}) *elasticsearch.SQLRequest {
+	sqlRequest := &elasticsearch.SQLRequest{}
+	sqlRequest.Query, _ = args["query"].(string)
+	sqlRequest.Filter, _ = args["filter"].(map[string]interface{})
+	sqlRequest.TimeZone, _ = args["time_zone"].(string)
+	if fetchSize, ok := args["fetch_size"].(float64); ok {
+		sqlRequest.FetchSize = int(fetchSize)
+	}
+	return sqlRequest
 }
 
-func (et *ElasticsearchTools) handleDocumentIndex(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
-	index, ok := args["index"].(string)
-	if !ok {
-		return createErrorResult("Missing or invalid 'index' parameter")
+func (et *ElasticsearchTools) handleBulk(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	operations, ok := args["operations"].([]interface{})
+	if !ok || len(operations) == 0 {
+		return createErrorResult("Missing or invalid 'operations' parameter")
 	}
 
-	body, ok := args["body"].(map[string]interface{})
-	if !ok {
-		return createErrorResult("Missing or invalid 'body' parameter")
+	// Validate all operations up front so that nothing is sent if any is invalid
+	bulkOps := make([]elasticsearch.BulkOperation, len(operations))
+	for i, op := range operations {
+		bulkOp, err := parseBulkOperation(op)
+		if err != nil {
+			return createErrorResult(fmt.Sprintf("Invalid operation at position %d: %v", i, err))
+		}
+		bulkOps[i] = *bulkOp
 	}
 
-	id, _ := args["id"].(string) // Optional parameter
+	refresh, err := parseRefresh(args)
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+
+	bulkOptions := &elasticsearch.BulkOptions{Refresh: refresh}
+	bulkOptions.Routing, _ = args["routing"].(string)
+	bulkOptions.Pipeline, _ = args["pipeline"].(string)
 
-	result, err := et.client.Index(ctx, index, id, body)
+	result, err := et.client.Bulk(ctx, bulkOps, bulkOptions)
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to index document: %v", err))
+		return createClientErrorResult("Failed to execute bulk operations", err)
 	}
 
-	return createSuccessResult("Document indexed successfully", result)
+	summary := summarizeBulk(result)
+	if summary.Failed > 0 {
+		errResult := createErrorResult(fmt.Sprintf("%d of %d bulk operations failed", summary.Failed, len(result.Items)))
+		errResult.StructuredContent = summary
+		return errResult
+	}
+
+	return createSuccessResult(fmt.Sprintf("All %d bulk operations succeeded", summary.Succeeded), summary)
 }
 
-func (et *ElasticsearchTools) handleDocumentGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
-	index, ok := args["index"].(string)
+// parseBulkOperation converts a bulk operation argument and checks that it
+// names a supported operation and index and has a body only where required
+func parseBulkOperation(value interface{}) (*elasticsearch.BulkOperation, error) {
+	opMap, ok := value.(map[string]interface{})
 	if !ok {
-		return createErrorResult("Missing or invalid 'index' parameter")
+		return nil, fmt.Errorf("expected an object")
 	}
 
-	id, ok := args["id"].(string)
-	if !ok {
-		return createErrorResult("Missing or invalid 'id' parameter")
+	bulkOp := &elasticsearch.BulkOperation{}
+	bulkOp.Operation, _ = opMap["operation"].(string)
+	bulkOp.Index, _ = opMap["index"].(string)
+	bulkOp.ID, _ = opMap["id"].(string)
+	if _, ok := opMap["type"]; ok {
+		return nil, fmt.Errorf("'type' is not supported, mapping types were removed in Elasticsearch 8")
 	}
 
-	result, err := et.client.Get(ctx, index, id)
-	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to get document: %v", err))
+	bulkOp.Routing, _ = opMap["routing"].(string)
+	bulkOp.Pipeline, _ = opMap["pipeline"].(string)
+	bulkOp.RequireAlias, _ = opMap["require_alias"].(bool)
+
+	body, hasBody := opMap["body"]
+	if hasBody {
+		if bulkOp.Body, ok = body.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("'body' must be an object")
+		}
 	}
 
-	return createSuccessResult("Document retrieved successfully", result)
+	if seqNo, ok := opMap["if_seq_no"].(float64); ok {
+		value := int(seqNo)
+		bulkOp.IfSeqNo = &value
+	}
+	if primaryTerm, ok := opMap["if_primary_term"].(float64); ok {
+		value := int(primaryTerm)
+		bulkOp.IfPrimaryTerm = &value
+	}
+	if (bulkOp.IfSeqNo == nil) != (bulkOp.IfPrimaryTerm == nil) {
+		return nil, fmt.Errorf("'if_seq_no' and 'if_primary_term' must be provided together")
+	}
+
+	// Update-only fields. A legacy update 'body' with 'doc' or 'script' is a
+	// full update body; anything else is the partial document.
+	updateParams := opMap
+	if bulkOp.Operation == "update" && hasBody {
+		_, hasDoc := bulkOp.Body["doc"]
+		_, hasScript := bulkOp.Body["script"]
+		if hasDoc || hasScript {
+			for _, field := range []string{"script", "upsert", "doc_as_upsert", "scripted_upsert"} {
+				if _, ok := opMap[field]; ok {
+					return nil, fmt.Errorf("'%s' cannot be combined with a 'body' containing 'doc' or 'script'", field)
+				}
+			}
+
+			updateParams = bulkOp.Body
+			bulkOp.Body = nil
+			if doc, ok := updateParams["doc"]; ok {
+				if bulkOp.Body, ok = doc.(map[string]interface{}); !ok {
+					return nil, fmt.Errorf("'body.doc' must be an object")
+				}
+			}
+			hasBody = bulkOp.Body != nil
+		}
+	}
+	bulkOp.Script = toScript(updateParams["script"])
+	bulkOp.Upsert, _ = updateParams["upsert"].(map[string]interface{})
+	bulkOp.DocAsUpsert, _ = updateParams["doc_as_upsert"].(bool)
+	bulkOp.ScriptedUpsert, _ = updateParams["scripted_upsert"].(bool)
+	if retries, ok := opMap["retry_on_conflict"].(float64); ok {
+		bulkOp.RetryOnConflict = int(retries)
+	}
+	hasUpdateFields := bulkOp.Script != nil || bulkOp.Upsert != nil || bulkOp.DocAsUpsert ||
+		bulkOp.ScriptedUpsert || bulkOp.RetryOnConflict != 0
+
+	if bulkOp.Index == "" {
+		return nil, fmt.Errorf("'index' is required")
+	}
+
+	switch bulkOp.Operation {
+	case "index", "create":
+		if !hasBody {
+			return nil, fmt.Errorf("'body' is required for %s operations", bulkOp.Operation)
+		}
+		if hasUpdateFields {
+			return nil, fmt.Errorf("'script', 'upsert', 'doc_as_upsert', 'scripted_upsert' and 'retry_on_conflict' are only allowed for update operations")
+		}
+	case "update":
+		if bulkOp.ID == "" {
+			return nil, fmt.Errorf("'id' is required for update operations")
+		}
+		if hasBody == (bulkOp.Script != nil) {
+			return nil, fmt.Errorf("exactly one of 'body' and 'script' is required for update operations")
+		}
+		if bulkOp.Pipeline != "" {
+			return nil, fmt.Errorf("'pipeline' is not allowed for update operations")
+		}
+	case "delete":
+		if bulkOp.ID == "" {
+			return nil, fmt.Errorf("'id' is required for delete operations")
+		}
+		if hasBody || hasUpdateFields || bulkOp.Pipeline != "" || bulkOp.RequireAlias {
+			return nil, fmt.Errorf("only 'routing', 'if_seq_no' and 'if_primary_term' are allowed for delete operations")
+		}
+	default:
+		return nil, fmt.Errorf("'operation' must be 'index', 'create', 'update' or 'delete'")
+	}
+
+	return bulkOp, nil
 }
 
-func (et *ElasticsearchTools) handleDocumentUpdate(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+// bulkSummary is a bulk response extended with success and failure counts
+// and the details of the failed items
+type bulkSummary struct {
+	*elasticsearch.BulkResponse
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Failures  []bulkFailure `json:"failures,omitempty"`
+}
+
+// bulkFailure describes a failed bulk item; Position is its index in the request
+type bulkFailure struct {
+	Position  int    `json:"position"`
+	Operation string `json:"operation"`
+	Index     string `json:"index"`
+	ID        string `json:"id,omitempty"`
+	Status    int    `json:"status"`
+	Type      string `json:"type,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// summarizeBulk counts the succeeded and failed items of a bulk response
+func summarizeBulk(result *elasticsearch.BulkResponse) *bulkSummary {
+	summary := &bulkSummary{BulkResponse: result}
+	for i, item := range result.Items {
+		// Each item holds a single entry keyed by its operation
+		for operation, itemResp := range item {
+			if itemResp.Error == nil {
+				summary.Succeeded++
+				continue
+			}
+
+			summary.Failed++
+			summary.Failures = append(summary.Failures, bulkFailure{
+				Position:  i,
+				Operation: operation,
+				Index:     itemResp.Index,
+				ID:        itemResp.ID,
+				Status:    itemResp.Status,
+				Type:      itemResp.Error.Type,
+				Reason:    itemResp.Error.Reason,
+			})
+		}
+	}
+	return summary
+}
+
+func (et *ElasticsearchTools) handleBulkIngest(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
 	index, ok := args["index"].(string)
 	if !ok {
 		return createErrorResult("Missing or invalid 'index' parameter")
 	}
 
-	id, ok := args["id"].(string)
-	if !ok {
-		return createErrorResult("Missing or invalid 'id' parameter")
+	data, hasData := args["data"].(string)
+	path, hasPath := args["path"].(string)
+	if hasData == hasPath {
+		return createErrorResult("Exactly one of 'data' and 'path' parameters is required")
 	}
 
-	body, ok := args["body"].(map[string]interface{})
-	if !ok {
-		return createErrorResult("Missing or invalid 'body' parameter")
+	format, _ := args["format"].(string)
+	if format != "" && format != "ndjson" && format != "json" && format != "csv" {
+		return createErrorResult("Invalid 'format' parameter: must be 'ndjson', 'json' or 'csv'")
+	}
+
+	opType, _ := args["op_type"].(string)
+	if opType != "" && opType != "index" && opType != "create" {
+		return createErrorResult("Invalid 'op_type' parameter: must be 'index' or 'create'")
+	}
+
+	refresh, err := parseRefresh(args)
+	if err != nil {
+		return createErrorResult(err.Error())
+	}
+
+	ingestRequest := &elasticsearch.BulkIngestRequest{
+		Index:   index,
+		Format:  format,
+		OpType:  opType,
+		Refresh: refresh,
+	}
+	ingestRequest.IDField, _ = args["id_field"].(string)
+	ingestRequest.Pipeline, _ = args["pipeline"].(string)
+	if workers, ok := args["workers"].(float64); ok {
+		ingestRequest.Workers = int(workers)
+	}
+	if flushBytes, ok := args["flush_bytes"].(float64); ok {
+		ingestRequest.FlushBytes = int(flushBytes)
+	}
+	if ingestRequest.FlushInterval, err = parseDuration(args, "flush_interval"); err != nil {
+		return createErrorResult(err.Error())
 	}
 
-	err := et.client.Update(ctx, index, id, body)
+	if hasData {
+		ingestRequest.Reader = strings.NewReader(data)
+	} else {
+		if et.ingestDir == "" {
+			return createErrorResult("File ingestion is disabled, set MCP_INGEST_DIR to enable it")
+		}
+		// MCP has no request for a server to read a client's resources, so
+		// resource URIs other than file:// cannot be ingested by reference
+		if strings.Contains(path, "://") && !strings.HasPrefix(path, "file://") {
+			return createErrorResult("Invalid 'path' parameter: reading MCP resources is not supported, only file paths and file:// URIs are; pass the resource content inline as 'data'")
+		}
+
+		file, err := openIngestFile(et.ingestDir, path)
+		if err != nil {
+			return createErrorResult(fmt.Sprintf("Failed to open 'path': %v", err))
+		}
+		defer file.Close()
+		ingestRequest.Reader = file
+	}
+
+	result, err := et.client.BulkIngest(ctx, ingestRequest)
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to update document: %v", err))
+		if result == nil {
+			return createClientErrorResult("Failed to ingest documents", err)
+		}
+		// Documents flushed before the error stay indexed, so report the progress too
+		errResult := createErrorResult(fmt.Sprintf("Ingestion stopped after %d documents: %v", result.Added, err))
+		errResult.StructuredContent = result
+		return errResult
 	}
 
-	return createSimpleSuccessResult(fmt.Sprintf("Document '%s' updated successfully in index '%s'", id, index))
+	succeeded := result.Indexed + result.Created
+	return createSuccessResult(fmt.Sprintf("Ingested %d documents into '%s' with %d failures in %dms (%.0f docs/s)",
+		succeeded, index, result.Failed, result.DurationMillis, result.DocsPerSecond), result)
 }
 
-func (et *ElasticsearchTools) handleDocumentDelete(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
-	index, ok := args["index"].(string)
-	if !ok {
-		return createErrorResult("Missing or invalid 'index' parameter")
+// openIngestFile opens a regular file inside root for es_bulk_ingest. Paths
+// and file:// URIs are resolved against root, including symbolic links, and
+// may not escape it.
+func openIngestFile(root, path string) (*os.File, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ingest directory: %w", err)
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return nil, fmt.Errorf("invalid ingest directory: %w", err)
 	}
 
-	id, ok := args["id"].(string)
-	if !ok {
-		return createErrorResult("Missing or invalid 'id' parameter")
+	path = strings.TrimPrefix(path, "file://")
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
 	}
 
-	err := et.client.Delete(ctx, index, id)
+	// Resolve symbolic links so that a link inside root cannot point outside of it
+	resolved, err := filepath.EvalSymlinks(path)
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to delete document: %v", err))
+		return nil, fmt.Errorf("file not found: %w", err)
 	}
 
-	return createSimpleSuccessResult(fmt.Sprintf("Document '%s' deleted successfully from index '%s'", id, index))
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("path must be inside the ingest directory")
+	}
+
+	file, err := os.Open(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		file.Close()
+		return nil, fmt.Errorf("'%s' is not a regular file", rel)
+	}
+
+	return file, nil
 }
 
-func (et *ElasticsearchTools) handleSearch(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
-	// Index is optional for search
-	index, _ := args["index"].(string)
+func (et *ElasticsearchTools) handleReindex(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	// Source index may be a single name or an array of names
+	var sourceIndex []string
+	switch v := args["source_index"].(type) {
+	case string:
+		sourceIndex = []string{v}
+	case []interface{}:
+		sourceIndex = toStringSlice(v)
+	}
+	if len(sourceIndex) == 0 {
+		return createErrorResult("Missing or invalid 'source_index' parameter")
+	}
+
+	destIndex, ok := args["dest_index"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'dest_index' parameter")
+	}
 
-	// Default query if none provided (this should be the query content, not wrapped in "query")
-	query := map[string]interface{}{
-		"match_all": map[string]interface{}{},
+	reindexRequest := &elasticsearch.ReindexRequest{
+		SourceIndex: sourceIndex,
+		DestIndex:   destIndex,
+		Script:      toScript(args["script"]),
+		Slices:      toSlices(args["slices"]),
 	}
-	if q, exists := args["query"]; exists {
-		if queryMap, ok := q.(map[string]interface{}); ok {
-			query = queryMap
-		}
+	reindexRequest.Query, _ = args["query"].(map[string]interface{})
+	reindexRequest.Pipeline, _ = args["pipeline"].(string)
+	reindexRequest.Conflicts, _ = args["conflicts"].(string)
+	reindexRequest.Refresh, _ = args["refresh"].(bool)
+	if maxDocs, ok := args["max_docs"].(float64); ok {
+		reindexRequest.MaxDocs = int(maxDocs)
 	}
 
-	// Default size
-	size := 10
-	if s, exists := args["size"]; exists {
-		if sizeInt, ok := s.(float64); ok {
-			size = int(sizeInt)
-		}
+	taskID, err := et.client.Reindex(ctx, reindexRequest)
+	if err != nil {
+		return createClientErrorResult("Failed to start reindex", err)
 	}
 
-	// Default from
-	from := 0
-	if f, exists := args["from"]; exists {
-		if fromInt, ok := f.(int64); ok {
-			from = int(fromInt)
-		}
+	result := map[string]interface{}{
+		"task": taskID,
 	}
 
-	// Parse sort parameter
-	var sort []interface{}
-	if s, exists := args["sort"]; exists {
-		if sortArray, ok := s.([]interface{}); ok {
-			sort = sortArray
-		}
+	return createSuccessResult(fmt.Sprintf("Reindex started as task '%s', poll it with es_task_get", taskID), result)
+}
+
+func (et *ElasticsearchTools) handleUpdateByQuery(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	byQueryRequest, err := parseByQueryRequest(args)
+	if err != nil {
+		return createErrorResult(err.Error())
 	}
+	byQueryRequest.Script = toScript(args["script"])
 
-	// Parse _source parameter
-	var source interface{}
-	if src, exists := args["_source"]; exists {
-		source = src
+	result, err := et.client.UpdateByQuery(ctx, byQueryRequest)
+	if err != nil {
+		return createClientErrorResult("Failed to update by query", err)
 	}
 
-	searchRequest := &elasticsearch.SearchRequest{
-		Index:  index,
-		Query:  query,
-		Size:   size,
-		From:   from,
-		Sort:   sort,
-		Source: source,
+	if result.Task != "" {
+		return createSuccessResult(fmt.Sprintf("Update by query started as task '%s', poll it with es_task_get", result.Task), result)
 	}
+	return createSuccessResult(fmt.Sprintf("Updated %d of %d documents, %d version conflicts, %d failures",
+		result.Updated, result.Total, result.VersionConflicts, len(result.Failures)), result)
+}
 
-	result, err := et.client.Search(ctx, searchRequest)
+func (et *ElasticsearchTools) handleDeleteByQuery(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	byQueryRequest, err := parseByQueryRequest(args)
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to execute search: %v", err))
+		return createErrorResult(err.Error())
+	}
+	if byQueryRequest.Query == nil {
+		return createErrorResult("Missing or invalid 'query' parameter")
+	}
+
+	result, err := et.client.DeleteByQuery(ctx, byQueryRequest)
+	if err != nil {
+		return createClientErrorResult("Failed to delete by query", err)
 	}
 
-	return createSuccessResult("Search executed successfully", result)
+	if result.Task != "" {
+		return createSuccessResult(fmt.Sprintf("Delete by query started as task '%s', poll it with es_task_get", result.Task), result)
+	}
+	return createSuccessResult(fmt.Sprintf("Deleted %d of %d documents, %d version conflicts, %d failures",
+		result.Deleted, result.Total, result.VersionConflicts, len(result.Failures)), result)
 }
 
-func (et *ElasticsearchTools) handleBulk(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
-	operations, ok := args["operations"].([]interface{})
+// parseByQueryRequest parses the arguments shared by update and delete by query
+func parseByQueryRequest(args map[string]interface{}) (*elasticsearch.ByQueryRequest, error) {
+	index, ok := args["index"].(string)
 	if !ok {
-		return createErrorResult("Missing or invalid 'operations' parameter")
+		return nil, fmt.Errorf("Missing or invalid 'index' parameter")
 	}
 
-	// Convert to BulkOperation slice
-	bulkOps := make([]elasticsearch.BulkOperation, len(operations))
-	for i, op := range operations {
-		if opMap, ok := op.(map[string]interface{}); ok {
-			// Convert the operation to the expected format
-			if opBytes, err := json.Marshal(opMap); err == nil {
-				var bulkOp elasticsearch.BulkOperation
-				if err := json.Unmarshal(opBytes, &bulkOp); err == nil {
-					bulkOps[i] = bulkOp
-				}
-			}
-		}
+	conflicts, _ := args["conflicts"].(string)
+	if conflicts != "" && conflicts != "abort" && conflicts != "proceed" {
+		return nil, fmt.Errorf("Invalid 'conflicts' parameter: must be 'abort' or 'proceed'")
+	}
+
+	byQueryRequest := &elasticsearch.ByQueryRequest{
+		Index:     []string{index},
+		Conflicts: conflicts,
+		Slices:    toSlices(args["slices"]),
+	}
+	byQueryRequest.Query, _ = args["query"].(map[string]interface{})
+	if maxDocs, ok := args["max_docs"].(float64); ok {
+		byQueryRequest.MaxDocs = int(maxDocs)
+	}
+	if rps, ok := args["requests_per_second"].(float64); ok {
+		requestsPerSecond := int(rps)
+		byQueryRequest.RequestsPerSecond = &requestsPerSecond
+	}
+	byQueryRequest.WaitForCompletion, _ = args["wait_for_completion"].(bool)
+	byQueryRequest.Refresh, _ = args["refresh"].(bool)
+
+	return byQueryRequest, nil
+}
+
+func (et *ElasticsearchTools) handleTaskGet(ctx context.Context, args map[string]interface{}) mcp.CallToolResult {
+	taskID, ok := args["task_id"].(string)
+	if !ok {
+		return createErrorResult("Missing or invalid 'task_id' parameter")
 	}
 
-	result, err := et.client.Bulk(ctx, bulkOps)
+	result, err := et.client.GetTask(ctx, taskID)
 	if err != nil {
-		return createErrorResult(fmt.Sprintf("Failed to execute bulk operations: %v", err))
+		return createErrorResult(fmt.Sprintf("Failed to get task: %v", err))
+	}
+
+	if !result.Completed {
+		if status := result.Task.ByQueryStatus(); status != nil {
+			processed := status.Created + status.Updated + status.Deleted + status.Noops + status.VersionConflicts
+			return createSuccessResult(fmt.Sprintf("Task '%s' is running: %d of %d documents processed", taskID, processed, status.Total), result)
+		}
+		return createSuccessResult(fmt.Sprintf("Task '%s' is running", taskID), result)
 	}
 
-	return createSuccessResult("Bulk operations executed successfully", result)
+	if result.Error != nil {
+		return createSuccessResult(fmt.Sprintf("Task '%s' failed", taskID), result)
+	}
+	if failures, ok := result.Response["failures"].([]interface{}); ok && len(failures) > 0 {
+		return createSuccessResult(fmt.Sprintf("Task '%s' completed with %d failures", taskID, len(failures)), result)
+	}
+	return createSuccessResult(fmt.Sprintf("Task '%s' completed successfully", taskID), result)
 }
@@ -0,0 +1,659 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AeaZer/mcp-elasticsearch/elasticsearch"
+)
+
+func TestParseSearchRequest(t *testing.T) {
+	matchAll := map[string]interface{}{"match_all": map[string]interface{}{}}
+	aggs := map[string]interface{}{"by_tag": map[string]interface{}{"terms": map[string]interface{}{"field": "tag"}}}
+	match := map[string]interface{}{"match": map[string]interface{}{"title": "go"}}
+	knn := map[string]interface{}{"field": "embedding", "query_vector": []interface{}{0.1, 0.2}, "k": float64(5)}
+	rrf := map[string]interface{}{"rrf": map[string]interface{}{}}
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		want elasticsearch.SearchRequest
+	}{
+		{
+			name: "defaults",
+			args: map[string]interface{}{},
+			want: elasticsearch.SearchRequest{Query: matchAll, Size: 10},
+		},
+		{
+			name: "aggs",
+			args: map[string]interface{}{"index": "docs", "size": float64(0), "aggs": aggs},
+			want: elasticsearch.SearchRequest{Index: "docs", Query: matchAll, Aggs: aggs},
+		},
+		{
+			name: "aggregations alias",
+			args: map[string]interface{}{"aggregations": aggs},
+			want: elasticsearch.SearchRequest{Query: matchAll, Size: 10, Aggs: aggs},
+		},
+		{
+			name: "hit details",
+			args: map[string]interface{}{
+				"highlight":                   map[string]interface{}{"fields": map[string]interface{}{"title": map[string]interface{}{}}},
+				"fields":                      []interface{}{"published"},
+				"explain":                     true,
+				"include_named_queries_score": true,
+			},
+			want: elasticsearch.SearchRequest{
+				Query:                    matchAll,
+				Size:                     10,
+				Highlight:                map[string]interface{}{"fields": map[string]interface{}{"title": map[string]interface{}{}}},
+				Fields:                   []interface{}{"published"},
+				Explain:                  true,
+				IncludeNamedQueriesScore: true,
+			},
+		},
+		{
+			// match_all would add a constant score to every kNN hit
+			name: "knn without query",
+			args: map[string]interface{}{"knn": knn},
+			want: elasticsearch.SearchRequest{Size: 10, Knn: knn},
+		},
+		{
+			name: "hybrid search with rank fusion",
+			args: map[string]interface{}{"query": match, "knn": []interface{}{knn}, "rank": rrf},
+			want: elasticsearch.SearchRequest{Query: match, Size: 10, Knn: []interface{}{knn}, Rank: rrf},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSearchRequest(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("request = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSearchRequestKnnErrors(t *testing.T) {
+	knn := map[string]interface{}{"field": "embedding", "query_vector": []interface{}{0.1, 0.2}}
+	match := map[string]interface{}{"match": map[string]interface{}{"title": "go"}}
+	rrf := map[string]interface{}{"rrf": map[string]interface{}{}}
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{name: "knn is not an object", args: map[string]interface{}{"knn": "embedding"}, wantErr: "must be an object or an array of objects"},
+		{name: "empty knn list", args: map[string]interface{}{"knn": []interface{}{}}, wantErr: "at least one clause is required"},
+		{name: "clause is not an object", args: map[string]interface{}{"knn": []interface{}{knn, "embedding"}}, wantErr: "clause 1 must be an object"},
+		{name: "clause without field", args: map[string]interface{}{"knn": map[string]interface{}{"query_vector": []interface{}{0.1}}}, wantErr: "clause 0 has no 'field'"},
+		{name: "clause without vector", args: map[string]interface{}{"knn": map[string]interface{}{"field": "embedding"}}, wantErr: "needs 'query_vector' or 'query_vector_builder'"},
+		{name: "rank without knn", args: map[string]interface{}{"query": match, "rank": rrf}, wantErr: "'rank' requires both 'query' and 'knn'"},
+		{name: "rank without query", args: map[string]interface{}{"knn": knn, "rank": rrf}, wantErr: "'rank' requires both 'query' and 'knn'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSearchRequest(tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// A query vector builder replaces the query vector
+	builder := map[string]interface{}{"field": "embedding", "query_vector_builder": map[string]interface{}{"text_embedding": map[string]interface{}{}}}
+	if _, err := parseSearchRequest(map[string]interface{}{"knn": builder}); err != nil {
+		t.Errorf("query_vector_builder: %v", err)
+	}
+}
+
+// sqlClient records the SQL requests of es_sql and answers with an empty page
+type sqlClient struct {
+	elasticsearch.Client
+	requests []*elasticsearch.SQLRequest
+}
+
+func (c *sqlClient) SQL(ctx context.Context, req *elasticsearch.SQLRequest) (*elasticsearch.SQLResponse, error) {
+	c.requests = append(c.requests, req)
+	return &elasticsearch.SQLResponse{}, nil
+}
+
+func TestHandleSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    *elasticsearch.SQLRequest
+		wantErr bool
+	}{
+		{
+			name: "query",
+			args: map[string]interface{}{"query": "SELECT * FROM library", "fetch_size": float64(5), "time_zone": "UTC"},
+			want: &elasticsearch.SQLRequest{Query: "SELECT * FROM library", FetchSize: 5, TimeZone: "UTC"},
+		},
+		{
+			name: "cursor ignores query settings",
+			args: map[string]interface{}{"cursor": "c2Vjb25k", "fetch_size": float64(5), "time_zone": "UTC"},
+			want: &elasticsearch.SQLRequest{Cursor: "c2Vjb25k"},
+		},
+		{name: "query and cursor", args: map[string]interface{}{"query": "SELECT 1", "cursor": "c2Vjb25k"}, wantErr: true},
+		{name: "neither query nor cursor", args: map[string]interface{}{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &sqlClient{}
+			result := NewElasticsearchTools(client, "").handleSQL(context.Background(), tt.args)
+			if result.IsError != tt.wantErr {
+				t.Fatalf("IsError = %v, wantErr %v", result.IsError, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(client.requests) != 0 {
+					t.Errorf("sent %d requests, want none", len(client.requests))
+				}
+				return
+			}
+			if len(client.requests) != 1 || !reflect.DeepEqual(client.requests[0], tt.want) {
+				t.Errorf("requests = %+v, want [%+v]", client.requests, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchCursorRoundTrip(t *testing.T) {
+	// Sort values beyond 2^53 must not lose precision
+	cursor := searchCursor{
+		PitID:       "pit-1",
+		KeepAlive:   "1m",
+		SearchAfter: []interface{}{json.Number("9007199254740993"), "doc-1"},
+		QueryHash:   "0123456789abcdef",
+	}
+
+	encoded, err := encodeSearchCursor(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := decodeSearchCursor(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*decoded, cursor) {
+		t.Errorf("decoded cursor = %+v, want %+v", *decoded, cursor)
+	}
+}
+
+func TestDecodeSearchCursorErrors(t *testing.T) {
+	noPit, err := encodeSearchCursor(searchCursor{SearchAfter: []interface{}{"a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "not base64", value: "not a cursor!"},
+		{name: "not json", value: "bm90IGpzb24"},
+		{name: "no point in time", value: noPit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeSearchCursor(tt.value); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestSearchQueryHash(t *testing.T) {
+	base := map[string]interface{}{
+		"query": map[string]interface{}{"match": map[string]interface{}{"title": "go"}},
+		"sort":  []interface{}{map[string]interface{}{"date": "desc"}},
+	}
+	hash := func(t *testing.T, args map[string]interface{}) string {
+		t.Helper()
+		req, err := parseSearchRequest(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h, err := searchQueryHash(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	want := hash(t, base)
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		same bool
+	}{
+		{name: "same query and sort", args: map[string]interface{}{"query": base["query"], "sort": base["sort"]}, same: true},
+		{name: "paging arguments are ignored", args: map[string]interface{}{"query": base["query"], "sort": base["sort"], "index": "other", "size": float64(50), "from": float64(10)}, same: true},
+		{name: "query omitted", args: map[string]interface{}{"sort": base["sort"]}},
+		{name: "sort omitted", args: map[string]interface{}{"query": base["query"]}},
+		{name: "different sort", args: map[string]interface{}{"query": base["query"], "sort": []interface{}{map[string]interface{}{"date": "asc"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hash(t, tt.args); (got == want) != tt.same {
+				t.Errorf("hash = %s, base hash = %s, want same = %v", got, want, tt.same)
+			}
+		})
+	}
+}
+
+func TestParseEQLSearchRequest(t *testing.T) {
+	base := map[string]interface{}{"index": "logs-*", "query": "process where true"}
+	with := func(extra map[string]interface{}) map[string]interface{} {
+		args := map[string]interface{}{}
+		for k, v := range base {
+			args[k] = v
+		}
+		for k, v := range extra {
+			args[k] = v
+		}
+		return args
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    *elasticsearch.EQLSearchRequest
+		wantErr bool
+	}{
+		{
+			name: "default timeout",
+			args: base,
+			want: &elasticsearch.EQLSearchRequest{Index: "logs-*", Query: "process where true", WaitForCompletionTimeout: eqlWaitForCompletionTimeout},
+		},
+		{
+			name: "all options",
+			args: with(map[string]interface{}{
+				"timestamp_field":             "event.created",
+				"tiebreaker_field":            "event.sequence",
+				"size":                        float64(50),
+				"wait_for_completion_timeout": "30s",
+				"keep_alive":                  "1h",
+			}),
+			want: &elasticsearch.EQLSearchRequest{
+				Index:                    "logs-*",
+				Query:                    "process where true",
+				TimestampField:           "event.created",
+				TiebreakerField:          "event.sequence",
+				Size:                     50,
+				WaitForCompletionTimeout: 30 * time.Second,
+				KeepAlive:                time.Hour,
+			},
+		},
+		{name: "missing query", args: map[string]interface{}{"index": "logs-*"}, wantErr: true},
+		{name: "missing index", args: map[string]interface{}{"query": "process where true"}, wantErr: true},
+		{name: "invalid timeout", args: with(map[string]interface{}{"wait_for_completion_timeout": "soon"}), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEQLSearchRequest(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("request = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenIngestFile(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "ingest")
+	mustMkdir(t, filepath.Join(root, "sub"))
+	mustWriteFile(t, filepath.Join(root, "data.ndjson"), `{"a":1}`)
+	mustWriteFile(t, filepath.Join(dir, "secret.txt"), "secret")
+	mustSymlink(t, filepath.Join(root, "data.ndjson"), filepath.Join(root, "inner"))
+	mustSymlink(t, filepath.Join(dir, "secret.txt"), filepath.Join(root, "outer"))
+	mustSymlink(t, dir, filepath.Join(root, "parent"))
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "relative path", path: "data.ndjson"},
+		{name: "file uri", path: "file://data.ndjson"},
+		{name: "absolute path inside root", path: filepath.Join(root, "data.ndjson")},
+		{name: "symlink inside root", path: "inner"},
+		{name: "parent directory", path: "../secret.txt", wantErr: true},
+		{name: "absolute path outside root", path: filepath.Join(dir, "secret.txt"), wantErr: true},
+		{name: "file uri outside root", path: "file://" + filepath.Join(dir, "secret.txt"), wantErr: true},
+		{name: "symlink to file outside root", path: "outer", wantErr: true},
+		{name: "symlink to directory outside root", path: filepath.Join("parent", "secret.txt"), wantErr: true},
+		{name: "directory", path: "sub", wantErr: true},
+		{name: "missing file", path: "missing.ndjson", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := openIngestFile(root, tt.path)
+			if file != nil {
+				file.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseBulkOperation(t *testing.T) {
+	doc := map[string]interface{}{"title": "test"}
+
+	tests := []struct {
+		name    string
+		op      interface{}
+		wantErr bool
+	}{
+		{name: "index", op: map[string]interface{}{"operation": "index", "index": "docs", "body": doc}},
+		{name: "index with id", op: map[string]interface{}{"operation": "index", "index": "docs", "id": "1", "body": doc}},
+		{name: "create", op: map[string]interface{}{"operation": "create", "index": "docs", "id": "1", "body": doc}},
+		{name: "update", op: map[string]interface{}{"operation": "update", "index": "docs", "id": "1", "body": doc}},
+		{name: "mapping type", op: map[string]interface{}{"operation": "index", "index": "docs", "type": "_doc", "body": doc}, wantErr: true},
+		{name: "delete", op: map[string]interface{}{"operation": "delete", "index": "docs", "id": "1"}},
+		{name: "not an object", op: "index", wantErr: true},
+		{name: "missing index", op: map[string]interface{}{"operation": "index", "body": doc}, wantErr: true},
+		{name: "unknown operation", op: map[string]interface{}{"operation": "upsert", "index": "docs", "body": doc}, wantErr: true},
+		{name: "index without body", op: map[string]interface{}{"operation": "index", "index": "docs"}, wantErr: true},
+		{name: "body is not an object", op: map[string]interface{}{"operation": "index", "index": "docs", "body": "doc"}, wantErr: true},
+		{name: "update without id", op: map[string]interface{}{"operation": "update", "index": "docs", "body": map[string]interface{}{"doc": doc}}, wantErr: true},
+		{name: "delete without id", op: map[string]interface{}{"operation": "delete", "index": "docs"}, wantErr: true},
+		{name: "delete with body", op: map[string]interface{}{"operation": "delete", "index": "docs", "id": "1", "body": doc}, wantErr: true},
+		{name: "index with metadata", op: map[string]interface{}{"operation": "index", "index": "docs", "body": doc, "routing": "r", "pipeline": "p", "require_alias": true}},
+		{name: "update with script", op: map[string]interface{}{"operation": "update", "index": "docs", "id": "1", "script": "ctx._source.n++", "upsert": doc, "scripted_upsert": true}},
+		{name: "update with retries", op: map[string]interface{}{"operation": "update", "index": "docs", "id": "1", "body": map[string]interface{}{"doc": doc}, "doc_as_upsert": true, "retry_on_conflict": float64(3)}},
+		{name: "update with body and script", op: map[string]interface{}{"operation": "update", "index": "docs", "id": "1", "body": map[string]interface{}{"doc": doc}, "script": "ctx._source.n++"}, wantErr: true},
+		{name: "update without body or script", op: map[string]interface{}{"operation": "update", "index": "docs", "id": "1"}, wantErr: true},
+		{name: "update with pipeline", op: map[string]interface{}{"operation": "update", "index": "docs", "id": "1", "body": map[string]interface{}{"doc": doc}, "pipeline": "p"}, wantErr: true},
+		{name: "index with update fields", op: map[string]interface{}{"operation": "index", "index": "docs", "body": doc, "retry_on_conflict": float64(3)}, wantErr: true},
+		{name: "delete with concurrency control", op: map[string]interface{}{"operation": "delete", "index": "docs", "id": "1", "routing": "r", "if_seq_no": float64(5), "if_primary_term": float64(1)}},
+		{name: "if_seq_no without if_primary_term", op: map[string]interface{}{"operation": "delete", "index": "docs", "id": "1", "if_seq_no": float64(5)}, wantErr: true},
+		{name: "delete with require_alias", op: map[string]interface{}{"operation": "delete", "index": "docs", "id": "1", "require_alias": true}, wantErr: true},
+		{name: "delete with pipeline", op: map[string]interface{}{"operation": "delete", "index": "docs", "id": "1", "pipeline": "p"}, wantErr: true},
+		{name: "update body with doc and script", op: map[string]interface{}{"operation": "update", "index": "docs", "id": "1", "body": map[string]interface{}{"doc": doc, "script": "ctx._source.n++"}}, wantErr: true},
+		{name: "update body with doc and top-level upsert", op: map[string]interface{}{"operation": "update", "index": "docs", "id": "1", "body": map[string]interface{}{"doc": doc}, "upsert": doc}, wantErr: true},
+		{name: "update body doc is not an object", op: map[string]interface{}{"operation": "update", "index": "docs", "id": "1", "body": map[string]interface{}{"doc": "title"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseBulkOperation(tt.op)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseBulkOperationUpdateBody(t *testing.T) {
+	doc := map[string]interface{}{"title": "test"}
+
+	tests := []struct {
+		name string
+		body map[string]interface{}
+		want elasticsearch.BulkOperation
+	}{
+		{
+			name: "partial document",
+			body: doc,
+			want: elasticsearch.BulkOperation{Body: doc},
+		},
+		{
+			name: "update body with doc",
+			body: map[string]interface{}{"doc": doc, "doc_as_upsert": true},
+			want: elasticsearch.BulkOperation{Body: doc, DocAsUpsert: true},
+		},
+		{
+			name: "update body with script",
+			body: map[string]interface{}{"script": "ctx._source.n++", "upsert": doc, "scripted_upsert": true},
+			want: elasticsearch.BulkOperation{
+				Script:         map[string]interface{}{"source": "ctx._source.n++"},
+				Upsert:         doc,
+				ScriptedUpsert: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBulkOperation(map[string]interface{}{"operation": "update", "index": "docs", "id": "1", "body": tt.body})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.want.Operation, tt.want.Index, tt.want.ID = "update", "docs", "1"
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("operation = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeBulk(t *testing.T) {
+	body := `{
+		"took": 3,
+		"errors": true,
+		"items": [
+			{"index": {"_index": "docs", "_id": "1", "status": 201, "result": "created"}},
+			{"create": {"_index": "docs", "_id": "2", "status": 409, "error": {"type": "version_conflict_engine_exception", "reason": "document already exists"}}},
+			{"delete": {"_index": "docs", "_id": "3", "status": 404, "result": "not_found"}}
+		]
+	}`
+	var result elasticsearch.BulkResponse
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	summary := summarizeBulk(&result)
+	if summary.Succeeded != 2 || summary.Failed != 1 {
+		t.Fatalf("succeeded = %d, failed = %d, want 2 and 1", summary.Succeeded, summary.Failed)
+	}
+
+	want := bulkFailure{
+		Position:  1,
+		Operation: "create",
+		Index:     "docs",
+		ID:        "2",
+		Status:    409,
+		Type:      "version_conflict_engine_exception",
+		Reason:    "document already exists",
+	}
+	if len(summary.Failures) != 1 || summary.Failures[0] != want {
+		t.Errorf("failures = %+v, want [%+v]", summary.Failures, want)
+	}
+}
+
+func TestParseUpdateRequest(t *testing.T) {
+	doc := map[string]interface{}{"title": "test"}
+	script := map[string]interface{}{"source": "ctx._source.n++"}
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    *elasticsearch.UpdateRequest
+		wantErr bool
+	}{
+		{
+			name: "partial document",
+			args: map[string]interface{}{"doc": doc, "doc_as_upsert": true, "retry_on_conflict": float64(3)},
+			want: &elasticsearch.UpdateRequest{Doc: doc, DocAsUpsert: true, RetryOnConflict: 3},
+		},
+		{
+			name: "script with upsert",
+			args: map[string]interface{}{"script": "ctx._source.n++", "upsert": doc, "scripted_upsert": true},
+			want: &elasticsearch.UpdateRequest{Script: script, Upsert: doc, ScriptedUpsert: true},
+		},
+		{
+			name: "deprecated body as partial document",
+			args: map[string]interface{}{"body": doc},
+			want: &elasticsearch.UpdateRequest{Doc: doc},
+		},
+		{
+			name: "deprecated full body",
+			args: map[string]interface{}{"body": map[string]interface{}{"script": script, "upsert": doc}, "retry_on_conflict": float64(2)},
+			want: &elasticsearch.UpdateRequest{Script: script, Upsert: doc, RetryOnConflict: 2},
+		},
+		{
+			name: "deprecated full body with retry_on_conflict",
+			args: map[string]interface{}{"body": map[string]interface{}{"doc": doc, "retry_on_conflict": float64(5)}},
+			want: &elasticsearch.UpdateRequest{Doc: doc, RetryOnConflict: 5},
+		},
+		{
+			name:    "retry_on_conflict in body and as parameter",
+			args:    map[string]interface{}{"body": map[string]interface{}{"doc": doc, "retry_on_conflict": float64(5)}, "retry_on_conflict": float64(2)},
+			wantErr: true,
+		},
+		{name: "body and doc", args: map[string]interface{}{"body": doc, "doc": doc}, wantErr: true},
+		{name: "body and upsert", args: map[string]interface{}{"body": map[string]interface{}{"doc": doc}, "upsert": doc}, wantErr: true},
+		{name: "neither doc nor script", args: map[string]interface{}{"upsert": doc}, wantErr: true},
+		{name: "doc and script", args: map[string]interface{}{"doc": doc, "script": "ctx._source.n++"}, wantErr: true},
+		{name: "invalid retry_on_conflict", args: map[string]interface{}{"doc": doc, "retry_on_conflict": "3"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUpdateRequest(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("update request = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDocumentOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		allowVersion  bool
+		allowIndexing bool
+		wantErr       bool
+	}{
+		{name: "no options", args: map[string]interface{}{}},
+		{name: "sequence number and primary term", args: map[string]interface{}{"if_seq_no": float64(5), "if_primary_term": float64(1)}},
+		{name: "sequence number only", args: map[string]interface{}{"if_seq_no": float64(5)}, wantErr: true},
+		{name: "primary term only", args: map[string]interface{}{"if_primary_term": float64(1)}, wantErr: true},
+		{name: "version allowed", args: map[string]interface{}{"version": float64(3), "version_type": "external"}, allowVersion: true},
+		{name: "version not allowed", args: map[string]interface{}{"version": float64(3)}, wantErr: true},
+		{name: "version type not allowed", args: map[string]interface{}{"version_type": "external"}, wantErr: true},
+		{name: "routing", args: map[string]interface{}{"routing": "user-1"}},
+		{name: "op_type create when indexing", args: map[string]interface{}{"op_type": "create"}, allowIndexing: true},
+		{name: "pipeline when indexing", args: map[string]interface{}{"pipeline": "enrich"}, allowIndexing: true},
+		{name: "invalid op_type", args: map[string]interface{}{"op_type": "update"}, allowIndexing: true, wantErr: true},
+		{name: "op_type when not indexing", args: map[string]interface{}{"op_type": "create"}, wantErr: true},
+		{name: "pipeline when not indexing", args: map[string]interface{}{"pipeline": "enrich"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDocumentOptions(tt.args, tt.allowVersion, tt.allowIndexing)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRefresh(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "not set", value: nil, want: ""},
+		{name: "bool true", value: true, want: "true"},
+		{name: "bool false", value: false, want: "false"},
+		{name: "string true", value: "true", want: "true"},
+		{name: "wait_for", value: "wait_for", want: "wait_for"},
+		{name: "empty string", value: "", want: ""},
+		{name: "invalid string", value: "later", wantErr: true},
+		{name: "number", value: float64(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]interface{}{}
+			if tt.value != nil {
+				args["refresh"] = tt.value
+			}
+
+			got, err := parseRefresh(args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("refresh = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "8.11.0", want: true},
+		{version: "8.11.0-SNAPSHOT", want: true},
+		{version: "8.12.1", want: true},
+		{version: "9.0.0", want: true},
+		{version: "8.10.4", want: false},
+		{version: "7.17.20", want: false},
+		{version: "8", want: true},
+		{version: "", want: true},
+		{version: "8.x", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := versionAtLeast(tt.version, 8, 11); got != tt.want {
+				t.Errorf("versionAtLeast(%q, 8, 11) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func mustMkdir(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustSymlink(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks are not supported: %v", err)
+	}
+}